@@ -0,0 +1,374 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// adaptUnitOfMeasure is a unit of measure code in the ADAPT representation system. Label is the package label of the
+// unit, which UnitFromLabel reads, or empty if the package has no equivalent, when Quantity is the quantity that the
+// package does not convert. A read-only code, such as seeds or mph, is read as the unit, but the unit is written with
+// another code.
+type adaptUnitOfMeasure struct {
+	code     string
+	label    string
+	quantity string
+	readOnly bool
+}
+
+// adaptUnitSystem is the table of unit of measure codes of the ADAPT representation system, by unit dimension, ref:
+// https://github.com/ADAPT/ADAPT. The tables that ParseADAPT, FormatADAPT and ADAPTUnmappedCodes use are built from
+// it, so that every code either resolves to a package unit or is reported as unmapped. ADAPT compound codes are
+// written in exponent form from these codes, eg kg1ha-1.
+var adaptUnitSystem = []adaptUnitOfMeasure{
+	// area
+	{code: "cm2", label: "cm2"},
+	{code: "m2", label: "m2"},
+	{code: "km2", label: "km2"},
+	{code: "ha", label: "ha"},
+	{code: "in2", label: "in2"},
+	{code: "ft2", label: "ft2"},
+	{code: "yd2", label: "yd2"},
+	{code: "mi2", label: "mi2"},
+	{code: "ac", label: "ac"},
+	// length
+	{code: "mm", label: "mm"},
+	{code: "cm", label: "cm"},
+	{code: "m", label: "m"},
+	{code: "km", label: "km"},
+	{code: "in", label: "in"},
+	{code: "ft", label: "ft"},
+	{code: "yd", label: "yd"},
+	{code: "mi", label: "mi"},
+	// mass
+	{code: "mg", label: "mg"},
+	{code: "g", label: "g"},
+	{code: "kg", label: "kg"},
+	{code: "t", label: "t"},
+	{code: "oz", label: "ozm"},
+	{code: "lb", label: "lb"},
+	{code: "ton", label: "ton"},
+	{code: "cwt", label: "cwt"},
+	// time
+	{code: "sec", label: "s"},
+	{code: "min", label: "min"},
+	{code: "hr", label: "h"},
+	{code: "day", label: "d"},
+	{code: "wk", label: "wk"},
+	{code: "yr", label: "yr"},
+	// volume
+	{code: "ml", label: "ml"},
+	{code: "l", label: "l"},
+	{code: "cm3", label: "cm3"},
+	{code: "m3", label: "m3"},
+	{code: "gal", label: "gal"},
+	{code: "floz", label: "floz"},
+	{code: "qt", label: "qt"},
+	{code: "pt", label: "pt"},
+	{code: "in3", label: "in3"},
+	{code: "ft3", label: "ft3"},
+	{code: "yd3", label: "yd3"},
+	{code: "bu", label: "bu"},
+	// speed
+	{code: "mph", label: "mi/h", readOnly: true},
+	// temperature
+	{code: "K", label: "K"},
+	{code: "C", label: "degC"},
+	{code: "F", label: "degF"},
+	// count
+	{code: "count", label: "count"},
+	{code: "seeds", label: "count", readOnly: true},
+	{code: "plants", label: "count", readOnly: true},
+	{code: "kernels", label: "count", readOnly: true},
+	// fraction
+	{code: "prcnt", label: "%"},
+	{code: "ppm", label: "ppm"},
+	// quantities that the package does not convert
+	{code: "kPa", quantity: "pressure"},
+	{code: "psi", quantity: "pressure"},
+	{code: "bar", quantity: "pressure"},
+	{code: "N", quantity: "force"},
+	{code: "lbf", quantity: "force"},
+	{code: "Nm", quantity: "torque"},
+	{code: "kW", quantity: "power"},
+	{code: "hp", quantity: "power"},
+	{code: "V", quantity: "electric potential"},
+	{code: "mA", quantity: "electric current"},
+	{code: "rpm", quantity: "rotational speed"},
+	{code: "arcdeg", quantity: "plane angle"},
+}
+
+// adaptUnits maps the ADAPT codes that have a package equivalent to their units.
+var adaptUnits = func() map[string]Unit {
+	m := make(map[string]Unit, len(adaptUnitSystem))
+	for _, x := range adaptUnitSystem {
+		if x.label == "" {
+			continue
+		}
+		u, err := adaptUnitFromLabel(x.label)
+		if err != nil {
+			panic(fmt.Sprintf("ADAPT unit %s: %v", x.code, err))
+		}
+		m[x.code] = u
+	}
+	return m
+}()
+
+// adaptCodes maps the standard label of each unit to the ADAPT code that it is written as. The units returned by
+// ADAPTUnmappedUnits have no ADAPT code.
+var adaptCodes = func() map[string]string {
+	m := make(map[string]string, len(adaptUnitSystem))
+	for _, x := range adaptUnitSystem {
+		if u, ok := adaptUnits[x.code]; ok && !x.readOnly {
+			m[u.String()] = x.code
+		}
+	}
+	return m
+}()
+
+// adaptUnmappedCodes are the ADAPT codes of quantities that the package does not convert, with the quantity of each.
+var adaptUnmappedCodes = func() map[string]string {
+	m := make(map[string]string)
+	for _, x := range adaptUnitSystem {
+		if x.label == "" {
+			m[x.code] = x.quantity
+		}
+	}
+	return m
+}()
+
+// adaptUnitFromLabel returns the unit for the package label of an ADAPT code. It reads simple and compound labels
+// without UnitFromLabel, which reads ADAPT codes itself.
+func adaptUnitFromLabel(label string) (Unit, error) {
+	if u, ok := lookupSimpleUnit(label); ok {
+		return u, nil
+	}
+	e, err := ParseUnitExpression(label)
+	if err != nil {
+		return nil, err
+	}
+	return unitFromTerms(e.Terms()), nil
+}
+
+// ADAPTRepresentation is an ADAPT variable representation, such as vrAppRateMassActual, with the dimension of the
+// units of measure that its values can have.
+type ADAPTRepresentation struct {
+	Code        string
+	Description string
+	Dimension   DimensionVector
+}
+
+// The dimensions of ADAPT representations. A volume per area application rate or yield is a length.
+var (
+	adaptMassPerArea   = DimensionVector{Mass: 1, Length: -2}
+	adaptVolumePerArea = DimensionVector{Length: 1}
+	adaptCountPerArea  = DimensionVector{Count: 1, Length: -2}
+	adaptSpeed         = DimensionVector{Length: 1, Time: -1}
+)
+
+// adaptRepresentations are the ADAPT application, seeding, yield, moisture and speed representations.
+var adaptRepresentations = []ADAPTRepresentation{
+	{Code: "vrAppRateMassActual", Description: "Actual mass application rate", Dimension: adaptMassPerArea},
+	{Code: "vrAppRateMassTarget", Description: "Target mass application rate", Dimension: adaptMassPerArea},
+	{Code: "vrAppRateVolumeActual", Description: "Actual volume application rate", Dimension: adaptVolumePerArea},
+	{Code: "vrAppRateVolumeTarget", Description: "Target volume application rate", Dimension: adaptVolumePerArea},
+	{Code: "vrSeedRateSeedsActual", Description: "Actual seeding rate", Dimension: adaptCountPerArea},
+	{Code: "vrSeedRateSeedsTarget", Description: "Target seeding rate", Dimension: adaptCountPerArea},
+	{Code: "vrYieldMass", Description: "Dry mass yield per area", Dimension: adaptMassPerArea},
+	{Code: "vrYieldWetMass", Description: "Wet mass yield per area", Dimension: adaptMassPerArea},
+	{Code: "vrYieldVolume", Description: "Volume yield per area", Dimension: adaptVolumePerArea},
+	{Code: "vrHarvestMoisture", Description: "Harvest moisture", Dimension: DimensionVector{}},
+	{Code: "vrVehicleSpeed", Description: "Vehicle speed", Dimension: adaptSpeed},
+}
+
+// ADAPTRepresentations returns the ADAPT representations that have a unit of measure mapping.
+func ADAPTRepresentations() []ADAPTRepresentation {
+	return append([]ADAPTRepresentation(nil), adaptRepresentations...)
+}
+
+// LookupADAPTRepresentation returns the ADAPT representation with the code, eg vrYieldMass, or an error if it has no
+// unit of measure mapping.
+func LookupADAPTRepresentation(code string) (ADAPTRepresentation, error) {
+	for _, r := range adaptRepresentations {
+		if r.Code == code {
+			return r, nil
+		}
+	}
+	return ADAPTRepresentation{}, fmt.Errorf("ADAPT representation %s has no unit of measure mapping", code)
+}
+
+// Unit returns the unit for an ADAPT unit of measure code of a value of the representation, eg t1ha-1 for
+// vrYieldMass. It returns an error if the code is not a unit of the representation, such as gal1ac-1 for vrYieldMass.
+func (r ADAPTRepresentation) Unit(code string) (Unit, error) {
+	u, err := ParseADAPT(code)
+	if err != nil {
+		return nil, err
+	}
+	d, err := DimensionVectorOf(u)
+	if err != nil {
+		return nil, err
+	}
+	if d != r.Dimension {
+		return nil, fmt.Errorf("ADAPT unit %s has dimension %s, but %s has dimension %s", code, d, r.Code, r.Dimension)
+	}
+	return u, nil
+}
+
+// ParseADAPT returns the unit for an ADAPT unit of measure code, eg bu1ac-1, kg1ha-1 or seeds1ac-1. Compound codes are
+// in exponent form, where a code such as m2 can be written as m with an exponent, eg kg1m-2, and every code after the
+// first must have an exponent, so that kgha is not read as kg·ha. Units that are not simple units, or mass/area,
+// volume/area or dilution rate units, are returned as a CompoundUnit. It returns an *UnmappedADAPTError if the code
+// has a unit with no equivalent in the package, or a *MalformedCompoundUnitError if an exponent is missing or out of
+// range.
+func ParseADAPT(code string) (Unit, error) {
+	if u, ok := adaptUnits[code]; ok {
+		return u, nil
+	}
+	var terms []UnitPower
+	for i := 0; i < len(code); {
+		t, n, explicit, err := matchADAPTTerm(code, i)
+		if err != nil {
+			return nil, err
+		}
+		if len(terms) > 0 && !explicit {
+			return nil, &MalformedCompoundUnitError{
+				Label:    code,
+				Position: i,
+				Reason:   fmt.Sprintf("expecting an exponent after %s, as in kg1ha-1", code[i:i+n]),
+			}
+		}
+		terms = append(terms, t)
+		i += n
+	}
+	if len(terms) == 0 {
+		return nil, &UnmappedADAPTError{Code: code}
+	}
+	return unitFromTerms(terms), nil
+}
+
+// FormatADAPT returns the ADAPT unit of measure code of the unit, eg gal1ac-1 for gal/ac. It returns an
+// *UnmappedADAPTError if the unit, or a unit in a compound unit, has no ADAPT code, such as the bale.
+func FormatADAPT(u Unit) (string, error) {
+	terms := unitTerms(u)
+	if len(terms) == 1 && terms[0].Exponent == 1 {
+		return adaptCode(terms[0].Unit)
+	}
+	var sb strings.Builder
+	for _, t := range terms {
+		code, err := adaptCode(t.Unit)
+		if err != nil {
+			return "", err
+		}
+		base, power := splitADAPTExponent(code)
+		sb.WriteString(base + strconv.Itoa(power*t.Exponent))
+	}
+	return sb.String(), nil
+}
+
+// ADAPTCodes returns the ADAPT unit of measure codes that are read by ParseADAPT, sorted.
+func ADAPTCodes() []string {
+	codes := make([]string, 0, len(adaptUnits))
+	for code := range adaptUnits {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ADAPTUnmappedCodes returns the ADAPT unit of measure codes that have no equivalent in the package, sorted. They are
+// units of quantities that the package does not convert, such as pressure and power.
+func ADAPTUnmappedCodes() []string {
+	codes := make([]string, 0, len(adaptUnmappedCodes))
+	for code := range adaptUnmappedCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ADAPTUnmappedUnits returns the standard labels of the simple units that have no ADAPT code, such as the quintal
+// and the bale, in the order of the unit tables.
+func ADAPTUnmappedUnits() []string {
+	var labels []string
+	for _, u := range simpleUnits() {
+		if _, ok := adaptCodes[u.String()]; !ok {
+			labels = append(labels, u.String())
+		}
+	}
+	return labels
+}
+
+// adaptCode returns the ADAPT code of a simple or scaled unit.
+func adaptCode(u Unit) (string, error) {
+	code, ok := adaptCodes[u.String()]
+	if !ok {
+		return "", &UnmappedADAPTError{Unit: u.String()}
+	}
+	return code, nil
+}
+
+// matchADAPTTerm returns the unit with the longest ADAPT code at byte offset i in code, with the exponent that follows
+// it, the length of the term, and whether the exponent is written. A code with no exponent has exponent 1. A code that
+// is a power of another code, eg m followed by -2, is read as that code where one exists, so kg1m-2 is kg/m2. It
+// returns an *UnmappedADAPTError if there is no code at i, or a *MalformedCompoundUnitError if the exponent is zero or
+// larger in magnitude than maxUnitExponent.
+func matchADAPTTerm(code string, i int) (UnitPower, int, bool, error) {
+	s := code[i:]
+	for n := len(s); n > 0; n-- {
+		u, ok := adaptUnits[s[:n]]
+		if !ok {
+			continue
+		}
+		e := adaptExponentLength(s[n:])
+		if e == 0 {
+			return UnitPower{Unit: u, Exponent: 1}, n, false, nil
+		}
+		exp, err := strconv.Atoi(s[n : n+e])
+		if err != nil || exp == 0 || abs(exp) > maxUnitExponent {
+			return UnitPower{}, 0, false, &MalformedCompoundUnitError{
+				Label:    code,
+				Position: i + n,
+				Reason:   fmt.Sprintf("exponent '%s' must not be zero or larger than %d", s[n:n+e], maxUnitExponent),
+			}
+		}
+		if exp != 1 && exp != -1 {
+			if p, ok := adaptUnits[s[:n]+strconv.Itoa(abs(exp))]; ok {
+				return UnitPower{Unit: p, Exponent: exp / abs(exp)}, n + e, true, nil
+			}
+		}
+		return UnitPower{Unit: u, Exponent: exp}, n + e, true, nil
+	}
+	return UnitPower{}, 0, false, &UnmappedADAPTError{Code: code}
+}
+
+// adaptExponentLength returns the length of the signed integer exponent at the start of s, or 0 if there is none.
+func adaptExponentLength(s string) int {
+	n := 0
+	if strings.HasPrefix(s, "-") {
+		n++
+	}
+	digits := len(s[n:]) - len(strings.TrimLeft(s[n:], "0123456789"))
+	if digits == 0 {
+		return 0
+	}
+	return n + digits
+}
+
+// splitADAPTExponent splits an ADAPT code such as m2 or ft3 into a base code and exponent, when the base is also an
+// ADAPT code.
+func splitADAPTExponent(code string) (string, int) {
+	base := strings.TrimRight(code, "0123456789")
+	if base == code {
+		return code, 1
+	}
+	if _, ok := adaptUnits[base]; !ok {
+		return code, 1
+	}
+	n, err := strconv.Atoi(code[len(base):])
+	if err != nil {
+		return code, 1
+	}
+	return base, n
+}
@@ -0,0 +1,247 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseADAPT(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg  string
+		want string
+	}{
+		"bushels per acre":     {arg: "bu1ac-1", want: "bu1ac-1"},
+		"mass per area":        {arg: "kg1ha-1", want: "kg1ha-1"},
+		"volume per area":      {arg: "gal1ac-1", want: "gal1ac-1"},
+		"seeds per acre":       {arg: "seeds1ac-1", want: "count1ac-1"},
+		"power of area code":   {arg: "kg1m-2", want: "kg1[m2]-1"},
+		"volume code":          {arg: "m3ha-1", want: "[m3]1ha-1"},
+		"speed":                {arg: "km1hr-1", want: "km1h-1"},
+		"hundredweight":        {arg: "cwt1ac-1", want: "cwt1ac-1"},
+		"speed code":           {arg: "mph", want: "mi1h-1"},
+		"percent":              {arg: "prcnt", want: "%"},
+		"temperature":          {arg: "C", want: "degC"},
+		"longest code matched": {arg: "mi1min-1", want: "mi1min-1"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := ParseADAPT(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, u.String())
+		})
+	}
+}
+
+func TestParseADAPT_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg          string
+		wantSentinel error
+		wantErr      string
+	}{
+		"unmapped quantity": {
+			arg:          "kPa",
+			wantSentinel: ErrUnmappedADAPT,
+			wantErr:      "unmapped ADAPT unit kPa: pressure is not supported",
+		},
+		"unknown code": {
+			arg:          "kg1furlong-1",
+			wantSentinel: ErrUnmappedADAPT,
+			wantErr:      "unmapped ADAPT unit kg1furlong-1",
+		},
+		"package label": {arg: "kg/ha", wantSentinel: ErrUnmappedADAPT, wantErr: "unmapped ADAPT unit kg/ha"},
+		"missing exponent": {
+			arg:          "kgha",
+			wantSentinel: ErrMalformedCompoundUnit,
+			wantErr:      "malformed compound unit kgha at position 2: expecting an exponent after ha, as in kg1ha-1",
+		},
+		"zero exponent": {
+			arg:          "kg1ha0",
+			wantSentinel: ErrMalformedCompoundUnit,
+			wantErr:      "malformed compound unit kg1ha0 at position 5: exponent '0' must not be zero or larger than 9",
+		},
+		"overflow exponent": {
+			arg:          "m99999999999999999999",
+			wantSentinel: ErrMalformedCompoundUnit,
+			wantErr: "malformed compound unit m99999999999999999999 at position 1: exponent '99999999999999999999' " +
+				"must not be zero or larger than 9",
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseADAPT(c.arg)
+			assert.True(t, errors.Is(err, c.wantSentinel), err)
+			assert.EqualError(t, err, c.wantErr)
+		})
+	}
+}
+
+func TestFormatADAPT(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg  Unit
+		want string
+	}{
+		"simple":          {arg: Hour, want: "hr"},
+		"volume per area": {arg: VolumeAreaRatioUnit{Numerator: Gallon, Denominator: Acre}, want: "gal1ac-1"},
+		"area code power": {arg: MassAreaRatioUnit{Numerator: Kilogram, Denominator: SquareMetre}, want: "kg1m-2"},
+		"count":           {arg: CompoundUnit{Terms: []UnitPower{{Unit: Each, Exponent: 1}, {Unit: Acre, Exponent: -1}}}, want: "count1ac-1"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FormatADAPT(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+
+	_, err := FormatADAPT(Bale)
+	assert.True(t, errors.Is(err, ErrUnmappedADAPT))
+	assert.EqualError(t, err, "unmapped ADAPT unit: unit bale has no ADAPT code")
+}
+
+// TestADAPTCodes checks that every ADAPT code is read as a unit that is written back as an equivalent code, and that
+// every simple unit has an ADAPT code or is reported as unmapped.
+func TestADAPTCodes(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range ADAPTCodes() {
+		u, err := ParseADAPT(code)
+		if !assert.NoError(t, err, code) {
+			continue
+		}
+		got, err := FormatADAPT(u)
+		if !assert.NoError(t, err, code) {
+			continue
+		}
+		back, err := ParseADAPT(got)
+		assert.NoError(t, err, code)
+		r, err := conversionRatio(u, back)
+		assert.NoError(t, err, code)
+		assert.Equal(t, "1", r.RatString(), code)
+	}
+
+	unmapped := make(map[string]bool)
+	for _, label := range ADAPTUnmappedUnits() {
+		unmapped[label] = true
+	}
+	for _, u := range simpleUnits() {
+		_, err := FormatADAPT(u)
+		if unmapped[u.String()] {
+			assert.True(t, errors.Is(err, ErrUnmappedADAPT), u.String())
+		} else {
+			assert.NoError(t, err, u.String())
+		}
+	}
+	assert.True(t, unmapped[BaleStandard.String()])
+
+	for _, code := range ADAPTUnmappedCodes() {
+		_, err := ParseADAPT(code)
+		assert.True(t, errors.Is(err, ErrUnmappedADAPT), code)
+	}
+}
+
+// TestADAPTUnitSystem checks that every code in the ADAPT unit system is read as a unit whose standard label
+// UnitFromLabel reads back, or is reported as unmapped.
+func TestADAPTUnitSystem(t *testing.T) {
+	t.Parallel()
+
+	for _, x := range adaptUnitSystem {
+		u, err := ParseADAPT(x.code)
+		if x.label == "" {
+			assert.True(t, errors.Is(err, ErrUnmappedADAPT), x.code)
+			assert.Contains(t, ADAPTUnmappedCodes(), x.code)
+			continue
+		}
+		if !assert.NoError(t, err, x.code) {
+			continue
+		}
+		assert.Contains(t, ADAPTCodes(), x.code)
+		back, err := UnitFromLabel(u.String())
+		if assert.NoError(t, err, x.code) {
+			assert.Equal(t, u.String(), back.String(), x.code)
+		}
+	}
+
+	got, err := ValueFromTo(2, "cwt1ac-1", "lb/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 200, got, 1e-9)
+	got, err = ValueFromTo(60, "mph", "km1hr-1")
+	assert.NoError(t, err)
+	assert.InDelta(t, 96.56064, got, 1e-9)
+}
+
+func TestADAPTRepresentation_Unit(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		representation string
+		code           string
+		want           string
+		wantErr        string
+	}{
+		"yield":           {representation: "vrYieldMass", code: "t1ha-1", want: "t1ha-1"},
+		"volume yield":    {representation: "vrYieldVolume", code: "bu1ac-1", want: "bu1ac-1"},
+		"seeding rate":    {representation: "vrSeedRateSeedsTarget", code: "seeds1ac-1", want: "count1ac-1"},
+		"moisture":        {representation: "vrHarvestMoisture", code: "prcnt", want: "%"},
+		"wrong dimension": {representation: "vrYieldMass", code: "gal1ac-1", wantErr: "ADAPT unit gal1ac-1 has dimension length, but vrYieldMass has dimension length⁻²·mass"},
+		"unknown":         {representation: "vrFuelRate", code: "l1hr-1", wantErr: "ADAPT representation vrFuelRate has no unit of measure mapping"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r, err := LookupADAPTRepresentation(c.representation)
+			var u Unit
+			if err == nil {
+				u, err = r.Unit(c.code)
+			}
+			if c.wantErr != "" {
+				assert.EqualError(t, err, c.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, u.String())
+		})
+	}
+}
+
+func TestValueFromTo_ADAPT(t *testing.T) {
+	t.Parallel()
+
+	got, err := ValueFromTo(10, "gal1ac-1", "l1ha-1")
+	assert.NoError(t, err)
+	assert.InDelta(t, 93.5396, got, 0.0001)
+
+	got, err = ValueFromTo(20, "C", "F")
+	assert.NoError(t, err)
+	assert.InDelta(t, 68, got, 0.0001)
+
+	_, err = ValueFromTo(1, "kPa", "psi")
+	assert.True(t, errors.Is(err, ErrUnmappedADAPT), err)
+
+	// Runs of codes with no exponents are typos, not products.
+	for _, label := range []string{"kgha", "tha", "lbac", "galac"} {
+		_, err = ValueFromTo(1, label, "kg/ha")
+		assert.True(t, errors.Is(err, ErrUnknownUnit), "%s: %v", label, err)
+	}
+
+	_, err = ValueFromTo(1, "m99999999999999999999", "%")
+	assert.True(t, errors.Is(err, ErrMalformedCompoundUnit), err)
+}
@@ -0,0 +1,245 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AmbiguousUnitError is returned by strict lookups when a label matches more than one unit. For example, "m" is
+// both the metre and an alias for the minute.
+type AmbiguousUnitError struct {
+	Label      string
+	Candidates []Unit
+}
+
+// Error satisfies the error interface.
+func (e *AmbiguousUnitError) Error() string {
+	xs := make([]string, len(e.Candidates))
+	for i, u := range e.Candidates {
+		xs[i] = fmt.Sprintf("%s (%s)", u.String(), DimensionOf(u))
+	}
+	return fmt.Sprintf("%s: %s could be any of %s", ErrAmbiguousUnit, e.Label, strings.Join(xs, ", "))
+}
+
+// Is allows errors.Is(err, ErrAmbiguousUnit) to match an AmbiguousUnitError.
+func (e *AmbiguousUnitError) Is(target error) bool {
+	return target == ErrAmbiguousUnit
+}
+
+// Ambiguity records a label that resolves to more than one unit.
+type Ambiguity struct {
+	Label      string
+	Candidates []Unit
+}
+
+// AmbiguousLabels checks every symbol, full name and alias in the unit tables and returns those that resolve to
+// more than one unit, or that mean different units by convention, such as gal and ton, sorted by label.
+func AmbiguousLabels() []Ambiguity {
+	seen := make(map[string]bool)
+	var xs []Ambiguity
+	for _, u := range simpleUnits() {
+		for _, label := range unitLabels(u) {
+			key := labelKey(label)
+			if label == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if c := labelCandidates(label); len(c) > 1 {
+				xs = append(xs, Ambiguity{Label: label, Candidates: c})
+			}
+		}
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].Label < xs[j].Label
+	})
+	return xs
+}
+
+// UnitFromLabelStrict is like UnitFromLabel but returns an *AmbiguousUnitError, rather than guessing, if the label,
+// or either part of a compound label, resolves to more than one unit or means different units by convention. The
+// case-sensitive labels, such as ml and Ml, are not ambiguous.
+func UnitFromLabelStrict(label string) (Unit, error) {
+	c := labelCandidates(label)
+	if len(c) > 1 {
+		return nil, &AmbiguousUnitError{Label: label, Candidates: c}
+	}
+	if len(c) == 1 {
+		return c[0], nil
+	}
+	n, d, err := splitCompoundUnit(label)
+	if err == nil {
+		for _, part := range []string{n, d} {
+			if c := labelCandidates(part); len(c) > 1 {
+				return nil, &AmbiguousUnitError{Label: part, Candidates: c}
+			}
+		}
+	}
+	return UnitFromLabel(label)
+}
+
+// UnitFromLabelWithDimension returns the unit for the label that has the dimension dim. It can be used to
+// disambiguate labels such as "m", which is a metre in LineDimension and a minute in TimeDimension.
+func UnitFromLabelWithDimension(label string, dim Dimension) (Unit, error) {
+	var u Unit
+	var err error
+	switch dim {
+	case AreaDimension:
+		u, err = areaUnitFromString(label)
+	case LineDimension:
+		u, err = lineUnitFromString(label)
+	case MassDimension:
+		u, err = massUnitFromString(label)
+	case TimeDimension:
+		u, err = timeUnitFromString(label)
+	case VolumeDimension:
+		u, err = volumeUnitFromString(label)
+	case TemperatureDimension:
+		u, err = temperatureUnitFromString(label)
+	case AmountDimension:
+		u, err = amountUnitFromString(label)
+	case CountDimension:
+		u, err = countUnitFromString(label)
+	case DimensionlessDimension:
+		u, err = fractionUnitFromString(label)
+	case MassAreaRatioDimension:
+		u, err = massAreaRatioUnitFromString(label)
+	case VolumeAreaRatioDimension:
+		u, err = volumeAreaRatioUnitFromString(label)
+	case DilutionRateDimension:
+		if !IsDilutionRateUnit(label) {
+			return nil, fmt.Errorf("unit label %s is not a dilution rate", label)
+		}
+		u, err = dilutionRateUnitFromString(label)
+	default:
+		return nil, fmt.Errorf("unhandled dimension: %s", dim)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no %s unit found for %s: %w", dim, label, err)
+	}
+	return u, nil
+}
+
+// simpleUnits returns every unit from the simple (non-compound) unit tables.
+func simpleUnits() []Unit {
+	var xs []Unit
+	for _, u := range areaUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range lineUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range massUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range timeUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range volumeUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range temperatureUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range amountUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range countUnits {
+		xs = append(xs, u)
+	}
+	for _, u := range fractionUnits {
+		xs = append(xs, u)
+	}
+	return xs
+}
+
+// conventionalReadings are labels that match one unit in the unit tables but mean another unit by a different
+// convention, keyed by the label in lower case, with the unit that UnitFromLabel returns first. For example, gal is
+// 3.785 l in the US and 4.546 l in the UK, and t is a tonne but a short ton in some US data.
+var conventionalReadings = map[string][]Unit{
+	"t":       {Tonne, Ton},
+	"ton":     {Ton, LongTon, Tonne},
+	"tons":    {Ton, LongTon, Tonne},
+	"gal":     {Gallon, ImperialGallon},
+	"gallon":  {Gallon, ImperialGallon},
+	"gallons": {Gallon, ImperialGallon},
+}
+
+// labelCandidates returns every unit that the label can mean, which are the conventional readings of the label, if
+// it has them, or otherwise the simple units that match it.
+func labelCandidates(label string) []Unit {
+	if xs, ok := conventionalReadings[strings.ToLower(label)]; ok {
+		return append([]Unit(nil), xs...)
+	}
+	return unitsMatching(label)
+}
+
+// unitsMatching returns every simple unit that matches the label, with the case-sensitive labels, such as ml and Ml,
+// matched with their case.
+func unitsMatching(label string) []Unit {
+	var xs []Unit
+	for _, u := range simpleUnitIndex.lookup(label) {
+		if unitMatches(u, label) {
+			xs = append(xs, u)
+		}
+	}
+	return xs
+}
+
+// unitMatches returns true if the simple unit u matches s.
+func unitMatches(u Unit, s string) bool {
+	switch v := u.(type) {
+	case AreaUnit:
+		return v.Matches(s)
+	case LineUnit:
+		return v.Matches(s)
+	case MassUnit:
+		return v.Matches(s)
+	case TimeUnit:
+		return v.Matches(s)
+	case VolumeUnit:
+		return v.Matches(s)
+	case TemperatureUnit:
+		return v.Matches(s)
+	case AmountUnit:
+		return v.Matches(s)
+	case CountUnit:
+		return v.Matches(s)
+	case FractionUnit:
+		return v.Matches(s)
+	}
+	return false
+}
+
+// unitLabels returns the standard label, fancy label, full name and aliases of a simple unit.
+func unitLabels(u Unit) []string {
+	switch v := u.(type) {
+	case AreaUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case LineUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case MassUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case TimeUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case VolumeUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case TemperatureUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case AmountUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case CountUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	case FractionUnit:
+		return append([]string{v.String(), v.fancy, v.full}, v.aliases...)
+	}
+	return nil
+}
+
+// labelKey folds the case of a label, except for the case-sensitive megalitre symbol.
+func labelKey(label string) string {
+	if label == string(MegalitreStandard) {
+		return label
+	}
+	return strings.ToLower(label)
+}
@@ -0,0 +1,129 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmbiguousLabels(t *testing.T) {
+	t.Parallel()
+	got := AmbiguousLabels()
+	assert.Equal(t, []Ambiguity{
+		{Label: "gal", Candidates: []Unit{Gallon, ImperialGallon}},
+		{Label: "gallon", Candidates: []Unit{Gallon, ImperialGallon}},
+		{Label: "gallons", Candidates: []Unit{Gallon, ImperialGallon}},
+		{Label: "m", Candidates: []Unit{Metre, Minute}},
+		{Label: "t", Candidates: []Unit{Tonne, Ton}},
+		{Label: "ton", Candidates: []Unit{Ton, LongTon, Tonne}},
+		{Label: "tons", Candidates: []Unit{Ton, LongTon, Tonne}},
+	}, got)
+}
+
+func TestUnitFromLabelStrict(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg            string
+		want           Unit
+		wantCandidates []Unit
+	}{
+		"unique simple unit": {
+			arg:  "kg",
+			want: Kilogram,
+		},
+		"megalitre is not millilitre": {
+			arg:  "Ml",
+			want: Megalitre,
+		},
+		"millilitre is not megalitre": {
+			arg:  "ml",
+			want: Millilitre,
+		},
+		"UCUM millilitre": {
+			arg:  "mL",
+			want: Millilitre,
+		},
+		"UCUM megalitre": {
+			arg:  "ML",
+			want: Megalitre,
+		},
+		"compound unit": {
+			arg:  "kg/ha",
+			want: MassAreaRatioUnit{Numerator: Kilogram, Denominator: Hectare},
+		},
+		"metre or minute": {
+			arg:            "m",
+			wantCandidates: []Unit{Metre, Minute},
+		},
+		"ambiguous denominator": {
+			arg:            "l/m",
+			wantCandidates: []Unit{Metre, Minute},
+		},
+		"tonne or ton": {
+			arg:            "t",
+			wantCandidates: []Unit{Tonne, Ton},
+		},
+		"short or long ton": {
+			arg:            "ton/ac",
+			wantCandidates: []Unit{Ton, LongTon, Tonne},
+		},
+		"US or imperial gallon": {
+			arg:            "gal",
+			wantCandidates: []Unit{Gallon, ImperialGallon},
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnitFromLabelStrict(c.arg)
+			if c.wantCandidates != nil {
+				assert.True(t, errors.Is(err, ErrAmbiguousUnit))
+				var ae *AmbiguousUnitError
+				assert.True(t, errors.As(err, &ae))
+				assert.Equal(t, c.wantCandidates, ae.Candidates)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestUnitFromLabelWithDimension(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		label   string
+		dim     Dimension
+		want    Unit
+		wantErr bool
+	}{
+		"m as a line":          {label: "m", dim: LineDimension, want: Metre},
+		"m as a time":          {label: "m", dim: TimeDimension, want: Minute},
+		"kg/ha as a mass/area": {label: "kg/ha", dim: MassAreaRatioDimension, want: MassAreaRatioUnit{Numerator: Kilogram, Denominator: Hectare}},
+		"g/l as a dilution": {label: "g/l", dim: DilutionRateDimension, want: RatioUnit{
+			Numerator:   Gram,
+			Denominator: Litre,
+		}},
+		"kg as an area":       {label: "kg", dim: AreaDimension, wantErr: true},
+		"kg/ha as a dilution": {label: "kg/ha", dim: DilutionRateDimension, wantErr: true},
+		"unknown dimension":   {label: "kg", dim: Dimension("colour"), wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnitFromLabelWithDimension(c.label, c.dim)
+			assert.Equal(t, c.wantErr, err != nil)
+			if !c.wantErr {
+				assert.Equal(t, c.want, got)
+				assert.Equal(t, c.dim, DimensionOf(got))
+			}
+		})
+	}
+}
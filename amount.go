@@ -0,0 +1,107 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Amount string
+
+const (
+	MicromoleStandard Amount = "umol"
+	MillimoleStandard Amount = "mmol"
+	MoleStandard      Amount = "mol"
+	KilomoleStandard  Amount = "kmol"
+)
+
+// String returns the string representation of the amount of substance unit.
+func (a Amount) String() string {
+	return string(a)
+}
+
+// AmountUnit represents a unit for an amount of substance.
+type AmountUnit struct {
+	unit       Amount
+	full       string
+	fancy      string
+	aliases    []string
+	conversion factor
+}
+
+// String returns the string representation of the base amount unit.
+func (u AmountUnit) String() string {
+	return u.unit.String()
+}
+
+// Matches returns true if s matches the amount unit.
+func (u AmountUnit) Matches(s string) bool {
+	if strings.EqualFold(u.String(), s) ||
+		strings.EqualFold(u.fancy, s) ||
+		strings.EqualFold(u.full, s) {
+		return true
+	}
+	for _, alias := range u.aliases {
+		if strings.EqualFold(alias, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// amountUnits is a list of all supported amount of substance units.
+var amountUnits = []AmountUnit{
+	Micromole,
+	Millimole,
+	Mole,
+	Kilomole,
+}
+
+var Micromole = AmountUnit{
+	unit:  MicromoleStandard,
+	full:  "micromole",
+	fancy: "µmol",
+	aliases: []string{
+		"micromoles",
+	},
+	conversion: exactFactor("0.000001"),
+}
+
+var Millimole = AmountUnit{
+	unit:  MillimoleStandard,
+	full:  "millimole",
+	fancy: string(MillimoleStandard),
+	aliases: []string{
+		"millimoles",
+	},
+	conversion: exactFactor("0.001"),
+}
+
+var Mole = AmountUnit{
+	unit:  MoleStandard,
+	full:  "mole",
+	fancy: string(MoleStandard),
+	aliases: []string{
+		"moles",
+	},
+	conversion: exactFactor("1"),
+}
+
+var Kilomole = AmountUnit{
+	unit:  KilomoleStandard,
+	full:  "kilomole",
+	fancy: string(KilomoleStandard),
+	aliases: []string{
+		"kilomoles",
+	},
+	conversion: exactFactor("1000"),
+}
+
+// amountUnitFromString returns the first amount unit that matches s.
+func amountUnitFromString(s string) (AmountUnit, error) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(AmountUnit); ok {
+			return u, nil
+		}
+	}
+	return AmountUnit{}, fmt.Errorf("no amount unit found for %s", s)
+}
@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_amountUnitFromString(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		argList  []string
+		wantUnit AmountUnit
+		wantErr  bool
+	}{
+		"micromole": {
+			argList:  []string{"umol", "µmol", "micromole", "micromoles"},
+			wantUnit: Micromole,
+			wantErr:  false,
+		},
+		"millimole": {
+			argList:  []string{"mmol", "millimole", "Millimoles"},
+			wantUnit: Millimole,
+			wantErr:  false,
+		},
+		"mole": {
+			argList:  []string{"mol", "mole", "moles"},
+			wantUnit: Mole,
+			wantErr:  false,
+		},
+		"kilomole": {
+			argList:  []string{"kmol", "kilomole", "kilomoles"},
+			wantUnit: Kilomole,
+			wantErr:  false,
+		},
+		"no match": {
+			argList:  []string{"mo", "molar", "M"},
+			wantUnit: AmountUnit{},
+			wantErr:  true,
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, arg := range c.argList {
+				gotUnit, err := amountUnitFromString(arg)
+				assert.Equal(t, c.wantErr, err != nil)
+				assert.Equal(t, c.wantUnit, gotUnit)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ type AreaUnit struct {
 	full       string
 	fancy      string
 	aliases    []string
-	conversion float64
+	conversion factor
 }
 
 // String returns the string representation of the base area unit.
@@ -77,7 +77,7 @@ var SquareCentimetre = AreaUnit{
 		"square centimetres",
 		"squared centimeters",
 	},
-	conversion: 0.0001,
+	conversion: exactFactor("0.0001"),
 }
 
 var SquareMetre = AreaUnit{
@@ -94,7 +94,7 @@ var SquareMetre = AreaUnit{
 		"square meter",
 		"squared meters",
 	},
-	conversion: 1,
+	conversion: exactFactor("1"),
 }
 
 var SquareKilometre = AreaUnit{
@@ -109,7 +109,7 @@ var SquareKilometre = AreaUnit{
 		"square kilometres",
 		"squared kilometers",
 	},
-	conversion: 1000000,
+	conversion: exactFactor("1000000"),
 }
 
 var Hectare = AreaUnit{
@@ -119,7 +119,7 @@ var Hectare = AreaUnit{
 	aliases: []string{
 		"hectares",
 	},
-	conversion: 10000,
+	conversion: exactFactor("10000"),
 }
 
 var SquareInch = AreaUnit{
@@ -128,11 +128,12 @@ var SquareInch = AreaUnit{
 	fancy:    "in²",
 	aliases: []string{
 		"in^2",
+		"sq in",
 		"inch squared",
 		"inches squared",
 		"square inches",
 	},
-	conversion: 0.00064516,
+	conversion: exactFactor("0.00064516"), // (0.0254 m)²
 }
 
 var SquareFoot = AreaUnit{
@@ -141,11 +142,12 @@ var SquareFoot = AreaUnit{
 	fancy:    "ft²",
 	aliases: []string{
 		"ft^2",
+		"sq ft",
 		"foot squared",
 		"feet squared",
 		"square feet",
 	},
-	conversion: 0.092903,
+	conversion: exactFactor("0.09290304"), // (0.3048 m)²
 }
 
 var SquareYard = AreaUnit{
@@ -154,11 +156,12 @@ var SquareYard = AreaUnit{
 	fancy:    "yd²",
 	aliases: []string{
 		"yd^2",
+		"sq yd",
 		"yard squared",
 		"yards squared",
 		"square yards",
 	},
-	conversion: 0.836127,
+	conversion: exactFactor("0.83612736"), // (0.9144 m)²
 }
 
 var SquareMile = AreaUnit{
@@ -167,11 +170,12 @@ var SquareMile = AreaUnit{
 	fancy:    "mi²",
 	aliases: []string{
 		"mi^2",
+		"sq mi",
 		"mile squared",
 		"miles squared",
 		"square miles",
 	},
-	conversion: 2589988.11,
+	conversion: exactFactor("2589988.110336"), // (1609.344 m)²
 }
 
 var Acre = AreaUnit{
@@ -181,13 +185,13 @@ var Acre = AreaUnit{
 	aliases: []string{
 		"acres",
 	},
-	conversion: 4046.86,
+	conversion: exactFactor("4046.8564224"), // 66 ft × 660 ft
 }
 
 // areaUnitFromString returns the first areaUnit that matches the search string, or nil if no match is found.
 func areaUnitFromString(s string) (AreaUnit, error) {
-	for _, u := range areaUnits {
-		if u.Matches(s) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(AreaUnit); ok {
 			return u, nil
 		}
 	}
@@ -203,8 +207,13 @@ type AreaMeasurement struct {
 // To converts an area measurement to the specified unit.
 func (m AreaMeasurement) To(unit AreaUnit) AreaMeasurement {
 	if m.Value != 0 {
-		m.Value = (m.Value * m.Unit.conversion) / unit.conversion
+		m.Value = scaleValue(m.Value, m.Unit.conversion, unit.conversion)
 	}
 	m.Unit = unit
 	return m
 }
+
+// UnitAs returns the unit of an area measurement in the format f, eg "ha" in SlashFormat.
+func (m AreaMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(m.Unit, f)
+}
@@ -0,0 +1,62 @@
+package convert
+
+import "fmt"
+
+// ConvertSlice converts each value in src from one unit to another and writes it to the same index in dst, which must
+// be at least as long as src. The units are resolved once, and it returns the same errors as NewConverter. dst and src
+// can be the same slice.
+func ConvertSlice(dst, src []float64, fromUnit, toUnit string) error {
+	c, err := NewConverter(fromUnit, toUnit)
+	if err != nil {
+		return err
+	}
+	return c.ConvertSlice(dst, src)
+}
+
+// ConvertSliceInPlace converts each value in values from one unit to another, replacing the value.
+func ConvertSliceInPlace(values []float64, fromUnit, toUnit string) error {
+	return ConvertSlice(values, values, fromUnit, toUnit)
+}
+
+// CropRateSlice converts each crop rate in src as CropRate does and writes it to the same index in dst, which must be
+// at least as long as src. The units and crop are resolved once, and it returns the same errors as CropRate. dst and
+// src can be the same slice.
+func CropRateSlice(crop string, dst, src []float64, fromCompoundUnit, toCompoundUnit string) error {
+	c, err := NewCropConverter(crop, fromCompoundUnit, toCompoundUnit)
+	if err != nil {
+		return err
+	}
+	return c.ConvertSlice(dst, src)
+}
+
+// ConvertSlice converts each value in src and writes it to the same index in dst, which must be at least as long as
+// src. dst and src can be the same slice.
+func (c *Converter) ConvertSlice(dst, src []float64) error {
+	if len(dst) < len(src) {
+		return fmt.Errorf("dst has length %d, which is less than the length %d of src", len(dst), len(src))
+	}
+	for i, v := range src {
+		dst[i] = c.Convert(v)
+	}
+	return nil
+}
+
+// NewCropConverter returns a Converter for crop rates, which converts values as CropRate does, with the bushel and
+// bale weights of the default factor set.
+func NewCropConverter(crop, fromCompoundUnit, toCompoundUnit string) (*Converter, error) {
+	return DefaultFactorSet().NewCropConverter(crop, fromCompoundUnit, toCompoundUnit)
+}
+
+// NewCropConverter returns a Converter for crop rates with the bushel and bale weights of the factor set. The scale
+// is the rate that CropRate converts a rate of 1 to, so the Converter takes the same conversion path as CropRate and
+// returns the same errors.
+func (s FactorSet) NewCropConverter(crop, fromUnit, toUnit string) (*Converter, error) {
+	scale, err := s.cropRate(crop, 1, fromUnit, toUnit)
+	if err != nil {
+		return nil, err
+	}
+	// The labels are known units unless they are the same label, which cropRate does not read
+	from, _ := UnitFromLabel(fromUnit)
+	to, _ := UnitFromLabel(toUnit)
+	return &Converter{from: from, to: to, scale: scale}, nil
+}
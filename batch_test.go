@@ -0,0 +1,119 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertSlice(t *testing.T) {
+	t.Parallel()
+
+	src := []float64{0, 1, 10, 100}
+	dst := make([]float64, len(src))
+	err := ConvertSlice(dst, src, "gal/ac", "l/ha")
+	assert.NoError(t, err)
+	for i, v := range src {
+		want, _ := ValueFromTo(v, "gal/ac", "l/ha")
+		assert.InDelta(t, want, dst[i], 1e-9)
+	}
+	assert.Equal(t, []float64{0, 1, 10, 100}, src, "src is not changed")
+
+	temps := []float64{0, 20, 100}
+	assert.NoError(t, ConvertSliceInPlace(temps, "degC", "degF"))
+	assert.InDeltaSlice(t, []float64{32, 68, 212}, temps, 1e-9)
+}
+
+func TestConvertSlice_Errors(t *testing.T) {
+	t.Parallel()
+
+	err := ConvertSlice(make([]float64, 1), make([]float64, 2), "kg", "lb")
+	assert.EqualError(t, err, "dst has length 1, which is less than the length 2 of src")
+
+	err = ConvertSlice(make([]float64, 1), make([]float64, 1), "kg", "l")
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions), err)
+}
+
+func TestCropRateSlice(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		crop     string
+		from, to string
+	}{
+		"mass to bushels": {crop: "corn", from: "t/ha", to: "bu/ac"},
+		"bushels to mass": {crop: "Wheat", from: "bu/ac", to: "kg/ha"},
+		"bales to mass":   {crop: "cotton", from: "bale/ac", to: "lb/ac"},
+		"mass to bales":   {crop: "cotton", from: "kg/ha", to: "bale/ac"},
+		"same units":      {crop: "corn", from: "kg/ha", to: "kg/ha"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			src := []float64{1, 2.5, 180}
+			dst := make([]float64, len(src))
+			err := CropRateSlice(c.crop, dst, src, c.from, c.to)
+			assert.NoError(t, err)
+			for i, v := range src {
+				want, err := CropRate(c.crop, v, c.from, c.to)
+				assert.NoError(t, err)
+				assert.InEpsilon(t, want, dst[i], 1e-12)
+			}
+		})
+	}
+
+	err := CropRateSlice("kale", make([]float64, 1), make([]float64, 1), "t/ha", "bu/ac")
+	assert.True(t, errors.Is(err, ErrUnknownCrop), err)
+}
+
+// benchmarkValues returns a column of yield values to convert.
+func benchmarkValues() []float64 {
+	values := make([]float64, 10000)
+	for i := range values {
+		values[i] = float64(i%300) + 0.5
+	}
+	return values
+}
+
+func BenchmarkValueFromTo_Loop(b *testing.B) {
+	src := benchmarkValues()
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, v := range src {
+			dst[i], _ = ValueFromTo(v, "bu/ac", "l/ha")
+		}
+	}
+}
+
+func BenchmarkConvertSlice(b *testing.B) {
+	src := benchmarkValues()
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = ConvertSlice(dst, src, "bu/ac", "l/ha")
+	}
+}
+
+func BenchmarkCropRate_Loop(b *testing.B) {
+	src := benchmarkValues()
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, v := range src {
+			dst[i], _ = CropRate("corn", v, "bu/ac", "t/ha")
+		}
+	}
+}
+
+func BenchmarkCropRateSlice(b *testing.B) {
+	src := benchmarkValues()
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = CropRateSlice("corn", dst, src, "bu/ac", "t/ha")
+	}
+}
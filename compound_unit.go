@@ -1,10 +1,55 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
+// UnitPower is a simple unit raised to an integer power within a CompoundUnit.
+type UnitPower struct {
+	Unit     Unit
+	Exponent int
+}
+
+// CompoundUnit is a product of simple units raised to integer powers, such as kg/m3 or t/ha/yr, that is not one of
+// the mass/area, volume/area or dilution rate units. It can be converted to any unit with the same DimensionVector.
+type CompoundUnit struct {
+	Terms []UnitPower
+}
+
+// String returns the compound unit in exponent form, eg kg1[m3]-1 or t1ha-1yr-1, and satisfies the Unit interface.
+func (u CompoundUnit) String() string {
+	var b strings.Builder
+	for _, t := range u.Terms {
+		b.WriteString(wrapUnitWithExponent(t.Unit))
+		b.WriteString(strconv.Itoa(t.Exponent))
+	}
+	return b.String()
+}
+
+// parseCompoundUnit parses a compound unit label, such as kg/m3, t/ha/yr or kg1[m3]-1, with ParseUnitExpression.
+func parseCompoundUnit(label string) (CompoundUnit, error) {
+	e, err := ParseUnitExpression(label)
+	if err != nil {
+		return CompoundUnit{}, err
+	}
+	terms := e.Terms()
+	if len(terms) == 0 || (len(terms) == 1 && terms[0].Exponent == 1) {
+		return CompoundUnit{}, fmt.Errorf("%s is not a compound unit", label)
+	}
+	return CompoundUnit{Terms: terms}, nil
+}
+
+// simpleUnitFromLabel returns the first simple unit that matches the label, in the same order as UnitFromLabel.
+func simpleUnitFromLabel(label string) (Unit, error) {
+	if u, ok := lookupSimpleUnit(label); ok {
+		return u, nil
+	}
+	return nil, &UnknownUnitError{Label: label}
+}
+
 // splitValueAreaCompoundUnit separates a compound unit string into numerator and denominator unit strings and verifies
 // that the numerator is a mass or volume unit and the denominator is an area unit.
 func splitValueAreaCompoundUnit(unit string) (string, string, error) {
@@ -13,93 +58,137 @@ func splitValueAreaCompoundUnit(unit string) (string, string, error) {
 		return "", "", err
 	}
 	if !IsMassUnit(n) && !IsVolumeUnit(n) {
-		return "", "", fmt.Errorf("compound unit %s has numerator %s, expecting a mass or volume unit", unit, n)
+		return "", "", fmt.Errorf("%w: compound unit %s has numerator %s, expecting a mass or volume unit",
+			ErrIncompatibleDimensions, unit, n)
 	}
 	if !IsAreaUnit(d) {
-		return "", "", fmt.Errorf("compound unit %s has denominator %s, expecting an area unit", unit, d)
+		return "", "", fmt.Errorf("compound unit %s has denominator %s: %w", unit, d, dimensionError(d, AreaDimension))
 	}
 	return n, d, nil
 }
 
-// splitCompoundUnit separates a compound Unit string into numerator and denominator Unit strings.
-// For example: "l1ha-1" OR l/ha -> "l", "ha"
+// splitCompoundUnit separates a compound Unit string into the standard labels of its numerator and denominator.
+// For example: "l1ha-1" OR l/ha OR litres per hectare -> "l", "ha"
+// It returns a *MalformedCompoundUnitError if the unit cannot be parsed or does not have exactly one numerator and
+// one denominator, or an *UnknownUnitError, with the position of the part in the unit, if the numerator or
+// denominator is not a known unit.
 func splitCompoundUnit(unit string) (string, string, error) {
-	if strings.Contains(unit, "-1") {
-		return splitCompoundUnitExponentForm(unit)
-	}
-	if strings.Contains(unit, "/") {
-		return splitCompoundUnitSlashForm(unit)
+	if v, ok := splitCompoundUnits.load(unit); ok {
+		p := v.(compoundParts)
+		return p.numerator, p.denominator, nil
 	}
-	if strings.Contains(unit, "per") {
-		return splitCompoundUnitPerForm(unit)
+	n, d, err := parseCompoundUnitParts(unit)
+	if err != nil {
+		return "", "", err
 	}
-	return "", "", fmt.Errorf("splitCompoundUnit() expects Unit string in exponent form (eg kg1ha-1) or slash form (eg kg/ha), got %s", unit)
+	splitCompoundUnits.store(unit, compoundParts{numerator: n, denominator: d})
+	return n, d, nil
 }
 
-func splitCompoundUnitExponentForm(unit string) (string, string, error) {
-	s := strings.TrimRight(unit, "-1")
-	xs := strings.Split(s, "1")
-	if len(xs) != 2 {
-		return "", "", fmt.Errorf("compound Unit %s split into %d parts, should be 2", unit, len(xs))
+// parseCompoundUnitParts parses a compound unit for splitCompoundUnit, which caches the result.
+func parseCompoundUnitParts(unit string) (string, string, error) {
+	if !strings.Contains(unit, "-1") && !strings.Contains(unit, "⁻¹") && !strings.Contains(unit, "/") &&
+		!strings.Contains(unit, "per") {
+		return "", "", &MalformedCompoundUnitError{
+			Label:  unit,
+			Reason: "expecting exponent form (eg kg1ha-1), slash form (eg kg/ha) or 'per' form (eg kg per ha)",
+		}
 	}
-
-	// Units with an exponent will generally be enclosed in square brackets which need To be removed.
-	// For example [m3]1[m2]-1 (cubic metres per square metre) should return "m3" and "m3"
-	n := strings.ToLower(strings.TrimSpace(strings.TrimRight(strings.TrimLeft(xs[0], "["), "]")))
-	_, err := UnitFromLabel(n)
+	e, err := ParseUnitExpression(unit)
 	if err != nil {
-		return "", "", fmt.Errorf("invalid numerator unit: %s", n)
+		var ue *UnknownUnitError
+		if errors.As(err, &ue) {
+			ue.Label = strings.ToLower(ue.Label)
+		}
+		return "", "", err
 	}
-
-	d := strings.ToLower(strings.TrimSpace(strings.TrimRight(strings.TrimLeft(xs[1], "["), "]")))
-	_, err = UnitFromLabel(d)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid denominator unit: %s", d)
+	n, d, ok := ratioExprParts(e)
+	if !ok {
+		return "", "", &MalformedCompoundUnitError{
+			Label:  unit,
+			Reason: fmt.Sprintf("expecting a numerator and a denominator, found %s", e),
+		}
 	}
-
-	return n, d, nil
+	return n.Unit.String(), d.Unit.String(), nil
 }
 
-func splitCompoundUnitSlashForm(unit string) (string, string, error) {
-	xs := strings.Split(unit, "/")
-	if len(xs) != 2 {
-		return "", "", fmt.Errorf("compound Unit %s split into %d parts, should be 2", unit, len(xs))
+// ratioExprParts returns the numerator and denominator of a unit expression that is one unit divided by another,
+// such as kg/ha, kg per ha or kg1ha-1.
+func ratioExprParts(e UnitExpr) (UnitRefExpr, UnitRefExpr, bool) {
+	var n, d UnitExpr
+	switch v := e.(type) {
+	case QuotientExpr:
+		n, d = v.Numerator, v.Denominator
+	case ProductExpr:
+		if len(v.Factors) != 2 {
+			return UnitRefExpr{}, UnitRefExpr{}, false
+		}
+		p, ok := v.Factors[1].(PowerExpr)
+		if !ok || p.Exponent != -1 {
+			return UnitRefExpr{}, UnitRefExpr{}, false
+		}
+		n, d = v.Factors[0], p.Base
+	default:
+		return UnitRefExpr{}, UnitRefExpr{}, false
 	}
-
-	n := strings.ToLower(strings.TrimSpace(xs[0]))
-	_, err := UnitFromLabel(n)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid numerator unit: %s", n)
+	nr, ok := unitRefExpr(n)
+	if !ok {
+		return UnitRefExpr{}, UnitRefExpr{}, false
 	}
+	dr, ok := unitRefExpr(d)
+	return nr, dr, ok
+}
 
-	d := strings.ToLower(strings.TrimSpace(xs[1]))
-	_, err = UnitFromLabel(d)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid denominator unit: %s", d)
+// unitFromExpr returns the unit of a parsed unit expression with unitFromTerms.
+func unitFromExpr(e UnitExpr) (Unit, error) {
+	terms := e.Terms()
+	if len(terms) == 0 {
+		return nil, &MalformedCompoundUnitError{Label: e.String(), Reason: "expected a unit"}
 	}
-
-	return n, d, nil
+	return unitFromTerms(terms), nil
 }
 
-func splitCompoundUnitPerForm(unit string) (string, string, error) {
-	xs := strings.Split(unit, "per")
-	if len(xs) != 2 {
-		return "", "", fmt.Errorf("compound Unit %s split into %d parts, should be 2", unit, len(xs))
+// unitFromTerms returns the unit that is the product of the terms, which is a simple or scaled unit, a mass/area,
+// volume/area or dilution rate unit as returned by UnitFromLabel, or a CompoundUnit.
+func unitFromTerms(terms []UnitPower) Unit {
+	if len(terms) == 1 && terms[0].Exponent == 1 {
+		return terms[0].Unit
+	}
+	if len(terms) == 2 && terms[0].Exponent == 1 && terms[1].Exponent == -1 {
+		n, d := terms[0].Unit, terms[1].Unit
+		switch nu := n.(type) {
+		case MassUnit:
+			if du, ok := d.(AreaUnit); ok {
+				return MassAreaRatioUnit{Numerator: nu, Denominator: du}
+			}
+		case VolumeUnit:
+			if du, ok := d.(AreaUnit); ok {
+				return VolumeAreaRatioUnit{Numerator: nu, Denominator: du}
+			}
+		}
+		if isMassOrVolumeUnit(n) && isMassOrVolumeUnit(d) {
+			return RatioUnit{Numerator: n, Denominator: d}
+		}
 	}
+	return CompoundUnit{Terms: terms}
+}
 
-	n := strings.ToLower(strings.TrimSpace(xs[0]))
-	_, err := UnitFromLabel(n)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid numerator unit: %s", n)
+// isMassOrVolumeUnit returns true if u is a mass or volume unit, ie a part of a dilution rate unit.
+func isMassOrVolumeUnit(u Unit) bool {
+	switch u.(type) {
+	case MassUnit, VolumeUnit:
+		return true
 	}
+	return false
+}
 
-	d := strings.ToLower(strings.TrimSpace(xs[1]))
-	_, err = UnitFromLabel(d)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid denominator unit: %s", d)
+// unitRefExpr returns the unit of an expression that is a single unit, optionally with an exponent of 1.
+func unitRefExpr(e UnitExpr) (UnitRefExpr, bool) {
+	if p, ok := e.(PowerExpr); ok && p.Exponent == 1 {
+		e = p.Base
 	}
-
-	return n, d, nil
+	r, ok := e.(UnitRefExpr)
+	return r, ok
 }
 
 // joinCompoundUnit returns numerator and denominator strings joined as a compound Unit string
@@ -25,22 +25,76 @@ type splitConversionUnits struct {
 }
 
 // ValueFromTo converts a numerical value from one unit To another. Params fromUnit and toUnit can be
-// simple units such as lb or kg, or compound units such as kg/ha or lb1ac-1.
-// It will return an error if fromUnit and toUnit are not compatible for conversion.
+// simple units such as lb or kg, or compound units such as kg/ha, lb1ac-1, kg/m3 or t/ha/yr. Any two units with the
+// same DimensionVector can be converted, and temperatures are converted with the offsets between the scales.
+// It will return an error if fromUnit and toUnit are not compatible for conversion: an *UnknownUnitError or a
+// *MalformedCompoundUnitError if a unit is not known, or an *IncompatibleDimensionsError if the units are known but
+// have different dimensions.
 func ValueFromTo(value float64, fromUnit string, toUnit string) (float64, error) {
 	if fromUnit == toUnit {
 		return value, nil
 	}
 	fn := conversionFunc(fromUnit, toUnit)
 	if fn == nil {
-		return 0, fmt.Errorf("cannot convert from %s to %s", fromUnit, toUnit)
+		return convertByDimension(value, fromUnit, toUnit)
 	}
 	return fn(value, fromUnit, toUnit)
 }
 
+// convertByDimension converts a value between units that do not have a specific conversion function, using the
+// dimension vectors of the units.
+func convertByDimension(value float64, fromUnit, toUnit string) (float64, error) {
+	from, to, err := conversionUnits(fromUnit, toUnit)
+	if err != nil {
+		return 0, err
+	}
+	return convertUnits(value, from, to)
+}
+
+// conversionUnits returns the units for the labels, or the reason that there is no conversion between them.
+func conversionUnits(fromUnit, toUnit string) (Unit, Unit, error) {
+	from, err := UnitFromLabel(fromUnit)
+	if err != nil {
+		return nil, nil, conversionError(fromUnit, toUnit)
+	}
+	to, err := UnitFromLabel(toUnit)
+	if err != nil {
+		return nil, nil, conversionError(fromUnit, toUnit)
+	}
+	fd, err := DimensionVectorOf(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	td, err := DimensionVectorOf(to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fd != td {
+		return nil, nil, newIncompatibleDimensionsError(fromUnit, toUnit)
+	}
+	return from, to, nil
+}
+
+// conversionError returns the reason that there is no conversion between the units.
+func conversionError(fromUnit, toUnit string) error {
+	for _, u := range []string{fromUnit, toUnit} {
+		if err := unitLabelError(u); err != nil {
+			return err
+		}
+	}
+	return newIncompatibleDimensionsError(fromUnit, toUnit)
+}
+
 // CropRate is a special conversion which can convert a MassMeasurement rate To a volume using known bushel conversions for
 // certain crops. If crop Value is not provided it will still do MassMeasurement-MassMeasurement or volume-volume conversions.
+// It returns an *UnknownCropError if a crop is needed and it is not one of the bushel or bale crops. The bushel and
+// bale weights are those of the default factor set.
 func CropRate(crop string, value float64, fromCompoundUnit, toCompoundUnit string) (float64, error) {
+	return DefaultFactorSet().cropRate(crop, value, fromCompoundUnit, toCompoundUnit)
+}
+
+// cropRate is CropRate with the bushel and bale weights of the factor set.
+func (s FactorSet) cropRate(crop string, value float64, fromCompoundUnit, toCompoundUnit string) (float64, error) {
 	// Nothing To do
 	if fromCompoundUnit == toCompoundUnit {
 		return value, nil
@@ -49,15 +103,18 @@ func CropRate(crop string, value float64, fromCompoundUnit, toCompoundUnit strin
 	// Converting MassMeasurement-MassMeasurement or volume-volume is a straightforward rate conversion
 	if (IsMassAreaRatioUnit(fromCompoundUnit) && IsMassUnit(toCompoundUnit)) ||
 		(IsVolumeAreaRatioUnit(fromCompoundUnit) && IsVolumeAreaRatioUnit(toCompoundUnit)) {
+		if len(s.unitFactors) > 0 {
+			return s.valueFromTo(value, fromCompoundUnit, toCompoundUnit)
+		}
 		return convertMassAreaMeasurement(value, fromCompoundUnit, toCompoundUnit)
 	}
 
 	// From here on we need To deal with a specific crop
 	crop = strings.ToLower(crop)
-	if !isBushelCrop(crop) && !isBaleCrop(crop) {
-		return 0, fmt.Errorf("unknown crop: %s", crop)
+	if !s.isBushelCrop(crop) && !s.isBaleCrop(crop) {
+		return 0, s.unknownCropError(crop)
 	}
-	return convertCropRate(crop, value, fromCompoundUnit, toCompoundUnit)
+	return s.convertCropRate(crop, value, fromCompoundUnit, toCompoundUnit)
 }
 
 // Round rounds a float64 to the specified number of decimal places
@@ -66,7 +123,9 @@ func Round(f float64, places int) float64 {
 	return math.Round(f*n) / n
 }
 
-// SplitCompoundUnit splits a compound unit into its numerator and denominator units.
+// SplitCompoundUnit splits a compound unit into its numerator and denominator units. It returns a
+// *MalformedCompoundUnitError if the unit cannot be split, or an *UnknownUnitError if the numerator or denominator is
+// not a known unit.
 func SplitCompoundUnit(unit string) (string, string, error) {
 	return splitCompoundUnit(unit)
 }
@@ -82,13 +141,13 @@ func convertAreaMeasurement(areaValue float64, fromUnit string, toUnit string) (
 	if maybeCompoundUnit(fromUnit) {
 		_, fromUnit, err = splitCompoundUnit(fromUnit)
 		if err != nil {
-			return 0, fmt.Errorf("could not split fromUnit as compound Unit: %s", err)
+			return 0, fmt.Errorf("could not split fromUnit as compound Unit: %w", err)
 		}
 	}
 	if maybeCompoundUnit(toUnit) {
 		_, toUnit, err = splitCompoundUnit(toUnit)
 		if err != nil {
-			return 0, fmt.Errorf("could not split toUnit as compound Unit: %s", err)
+			return 0, fmt.Errorf("could not split toUnit as compound Unit: %w", err)
 		}
 	}
 	from, err := areaUnitFromString(fromUnit)
@@ -138,13 +197,13 @@ func convertMassMeasurement(value float64, fromUnit, toUnit string) (float64, er
 	if maybeCompoundUnit(fromUnit) {
 		fromUnit, _, err = splitCompoundUnit(fromUnit)
 		if err != nil {
-			return 0, fmt.Errorf("could not split fromUnit as compound Unit: %s", err)
+			return 0, fmt.Errorf("could not split fromUnit as compound Unit: %w", err)
 		}
 	}
 	if maybeCompoundUnit(toUnit) {
 		toUnit, _, err = splitCompoundUnit(toUnit)
 		if err != nil {
-			return 0, fmt.Errorf("could not split toUnit as compound Unit: %s", err)
+			return 0, fmt.Errorf("could not split toUnit as compound Unit: %w", err)
 		}
 	}
 	from, err := massUnitFromString(fromUnit)
@@ -174,13 +233,13 @@ func convertVolumeMeasurement(value float64, fromUnit, toUnit string) (float64,
 	if maybeCompoundUnit(fromUnit) {
 		fromUnit, _, err = splitCompoundUnit(fromUnit)
 		if err != nil {
-			return 0, fmt.Errorf("could not split fromUnit as compound Unit: %s", err)
+			return 0, fmt.Errorf("could not split fromUnit as compound Unit: %w", err)
 		}
 	}
 	if maybeCompoundUnit(toUnit) {
 		toUnit, _, err = splitCompoundUnit(toUnit)
 		if err != nil {
-			return 0, fmt.Errorf("could not split toUnit as compound Unit: %s", err)
+			return 0, fmt.Errorf("could not split toUnit as compound Unit: %w", err)
 		}
 	}
 	from, err := volumeUnitFromString(fromUnit)
@@ -284,13 +343,13 @@ func checkConversionUnits(conversion conversionType, fromUnit, toUnit string) (*
 	case massAreaConversion, volumeAreaConversion:
 		units.fromNumerator, units.fromDenominator, err = splitValueAreaCompoundUnit(fromUnit)
 		if err != nil {
-			return nil, fmt.Errorf("incorrect source unit for conversion %s: %s", fromUnit, err)
+			return nil, fmt.Errorf("incorrect source unit for conversion %s: %w", fromUnit, err)
 		}
 
 		// numerator and denominator of the toUnit
 		units.toNumerator, units.toDenominator, err = splitValueAreaCompoundUnit(toUnit)
 		if err != nil {
-			return nil, fmt.Errorf("incorrect target unit for conversion %s: %s", toUnit, err)
+			return nil, fmt.Errorf("incorrect target unit for conversion %s: %w", toUnit, err)
 		}
 
 		// both denominators must be an area unit
@@ -303,21 +362,23 @@ func checkConversionUnits(conversion conversionType, fromUnit, toUnit string) (*
 
 		// Cannot convert between mass and volume numerators
 		if IsMassUnit(units.fromNumerator) && IsVolumeUnit(units.toNumerator) {
-			return nil, fmt.Errorf("cannot convert from mass (%s) to volume (%s)", fromUnit, toUnit)
+			return nil, newIncompatibleDimensionsError(fromUnit, toUnit)
 		}
 		if IsVolumeUnit(units.fromNumerator) && IsMassUnit(units.toNumerator) {
-			return nil, fmt.Errorf("cannot convert from volume (%s) to mass (%s)", fromUnit, toUnit)
+			return nil, newIncompatibleDimensionsError(fromUnit, toUnit)
 		}
 
 		// finally, do we have the correct units for the specified conversion
 		switch conversion {
 		case massAreaConversion:
 			if !IsMassUnit(units.fromNumerator) || !IsMassUnit(units.toNumerator) {
-				return nil, fmt.Errorf("incorrect units for mass / area conversion: %s and %s", fromUnit, toUnit)
+				return nil, fmt.Errorf("incorrect units for mass / area conversion: %w",
+					newIncompatibleDimensionsError(fromUnit, toUnit))
 			}
 		case volumeAreaConversion:
 			if !IsVolumeUnit(units.fromNumerator) || !IsVolumeUnit(units.toNumerator) {
-				return nil, fmt.Errorf("incorrect units for volume / area conversion: %s and %s", fromUnit, toUnit)
+				return nil, fmt.Errorf("incorrect units for volume / area conversion: %w",
+					newIncompatibleDimensionsError(fromUnit, toUnit))
 			}
 		}
 		// otherwise, we're good!
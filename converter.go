@@ -0,0 +1,69 @@
+package convert
+
+// Converter converts values from one unit to another, with the units resolved once by NewConverter, so that it can be
+// used in a loop over many values. A Converter is not changed after it is made, so it is safe for concurrent use.
+type Converter struct {
+	from   Unit
+	to     Unit
+	scale  float64
+	offset float64
+}
+
+// NewConverter returns a Converter from one unit label to another. The labels are the same as for ValueFromTo, and
+// it returns the same errors: an *UnknownUnitError or a *MalformedCompoundUnitError if a unit is not known, or an
+// *IncompatibleDimensionsError if the units are known but have different dimensions. As with ValueFromTo, a label
+// is not read if it is converted to itself, and the Converter returns values unchanged.
+func NewConverter(fromUnit, toUnit string) (*Converter, error) {
+	if fromUnit == toUnit {
+		u, _ := UnitFromLabel(fromUnit)
+		return &Converter{from: u, to: u, scale: 1}, nil
+	}
+	from, to, err := conversionUnits(fromUnit, toUnit)
+	if err != nil {
+		return nil, err
+	}
+	return newUnitConverter(from, to)
+}
+
+// newUnitConverter returns a Converter between two units with the same dimension.
+func newUnitConverter(from, to Unit) (*Converter, error) {
+	if f, ok := from.(TemperatureUnit); ok {
+		if t, ok := to.(TemperatureUnit); ok {
+			scale, offset := temperatureScale(f, t)
+			return &Converter{from: from, to: to, scale: floatValue(scale), offset: floatValue(offset)}, nil
+		}
+	}
+	r, err := conversionRatio(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &Converter{from: from, to: to, scale: floatValue(r)}, nil
+}
+
+// Convert returns the value converted to the unit of the Converter, which is value × Scale + Offset. The result is
+// within MaxRelativeError of the exact conversion, other than for a temperature close to zero on the scale.
+func (c *Converter) Convert(value float64) float64 {
+	return value*c.scale + c.offset
+}
+
+// Scale returns the number of 'to' units in one 'from' unit, rounded to float64.
+func (c *Converter) Scale() float64 {
+	return c.scale
+}
+
+// Offset returns the value that is added after scaling, which is only non-zero between temperature scales, eg 32
+// from degC to degF.
+func (c *Converter) Offset() float64 {
+	return c.offset
+}
+
+// From returns the unit that values are converted from. It is nil if the unit is not known and is converted to
+// itself.
+func (c *Converter) From() Unit {
+	return c.from
+}
+
+// To returns the unit that values are converted to. It is nil if the unit is not known and is converted from itself.
+func (c *Converter) To() Unit {
+	return c.to
+}
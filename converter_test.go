@@ -0,0 +1,114 @@
+package convert
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConverter(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		from, to   string
+		value      float64
+		wantScale  float64
+		wantOffset float64
+	}{
+		"area":            {from: "ha", to: "ac", value: 10, wantScale: 2.4710538146716536},
+		"mass per area":   {from: "kg/ha", to: "lb/ac", value: 100, wantScale: 0.8921791216197045},
+		"volume per area": {from: "gal/ac", to: "l/ha", value: 10, wantScale: 9.353956228956228},
+		"compound":        {from: "t/ha/yr", to: "kg/m2/d", value: 12, wantScale: 0.0002739726027397260},
+		"scaled":          {from: "kg/ha", to: "lb/1000 ft2", value: 50, wantScale: 0.020481614362252},
+		"ucum":            {from: "[gal_us]/[acr_us]", to: "L/har", value: 10, wantScale: 9.353956228956228},
+		"temperature":     {from: "degC", to: "degF", value: 20, wantScale: 1.8, wantOffset: 32},
+		"same label":      {from: "kg", to: "kg", value: 3, wantScale: 1},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			conv, err := NewConverter(c.from, c.to)
+			assert.NoError(t, err)
+			assert.InEpsilon(t, c.wantScale, conv.Scale(), 1e-12)
+			assert.InDelta(t, c.wantOffset, conv.Offset(), 1e-12)
+			want, err := ValueFromTo(c.value, c.from, c.to)
+			assert.NoError(t, err)
+			assert.InEpsilon(t, want, conv.Convert(c.value), MaxRelativeError)
+		})
+	}
+}
+
+func TestNewConverter_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		from, to string
+		wantErr  error
+	}{
+		"unknown unit":           {from: "kg/furlong", to: "kg/ha", wantErr: ErrUnknownUnit},
+		"incompatible":           {from: "kg", to: "l", wantErr: ErrIncompatibleDimensions},
+		"incompatible compounds": {from: "kg/ha", to: "l/ha", wantErr: ErrIncompatibleDimensions},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewConverter(c.from, c.to)
+			assert.True(t, errors.Is(err, c.wantErr), err)
+			_, want := ValueFromTo(1, c.from, c.to)
+			assert.Equal(t, want, err)
+		})
+	}
+}
+
+// TestConverter_SimpleUnits checks that a Converter gives the same result as ValueFromTo for every pair of simple
+// units with the same dimension.
+func TestConverter_SimpleUnits(t *testing.T) {
+	t.Parallel()
+
+	for _, from := range simpleUnits() {
+		for _, to := range simpleUnits() {
+			if DimensionOf(from) != DimensionOf(to) {
+				continue
+			}
+			conv, err := NewConverter(from.String(), to.String())
+			if !assert.NoError(t, err, "%s to %s", from, to) {
+				continue
+			}
+			want, err := ValueFromTo(123.45, from.String(), to.String())
+			assert.NoError(t, err)
+			assert.InEpsilon(t, want, conv.Convert(123.45), 4*MaxRelativeError, "%s to %s", from, to)
+		}
+	}
+}
+
+func TestConverter_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	conv, err := NewConverter("bu/ac", "l/ha")
+	assert.NoError(t, err)
+	want := conv.Convert(150)
+
+	var wg sync.WaitGroup
+	got := make([]float64, 8)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				got[i] = conv.Convert(150)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, g := range got {
+		assert.Equal(t, want, g)
+	}
+	assert.Equal(t, "bu1ac-1", conv.From().String())
+	assert.Equal(t, "l1ha-1", conv.To().String())
+}
@@ -0,0 +1,104 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Count string
+
+const (
+	CountStandard    Count = "count"
+	DozenStandard    Count = "doz"
+	ThousandStandard Count = "thousand"
+)
+
+// String returns the string representation of the count unit.
+func (c Count) String() string {
+	return string(c)
+}
+
+// CountUnit represents a unit for a number of discrete things, such as seeds or plants.
+type CountUnit struct {
+	unit       Count
+	full       string
+	fancy      string
+	aliases    []string
+	conversion factor
+}
+
+// String returns the string representation of the base count unit.
+func (u CountUnit) String() string {
+	return u.unit.String()
+}
+
+// Matches returns true if s matches the count unit.
+func (u CountUnit) Matches(s string) bool {
+	if strings.EqualFold(u.String(), s) ||
+		strings.EqualFold(u.fancy, s) ||
+		strings.EqualFold(u.full, s) {
+		return true
+	}
+	for _, alias := range u.aliases {
+		if strings.EqualFold(alias, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// countUnits is a list of all supported count units.
+var countUnits = []CountUnit{
+	Each,
+	Dozen,
+	Thousand,
+}
+
+var Each = CountUnit{
+	unit:  CountStandard,
+	full:  "count",
+	fancy: string(CountStandard),
+	aliases: []string{
+		"counts",
+		"ct",
+		"each",
+		"ea",
+		"seed",
+		"seeds",
+		"plant",
+		"plants",
+	},
+	conversion: exactFactor("1"),
+}
+
+var Dozen = CountUnit{
+	unit:  DozenStandard,
+	full:  "dozen",
+	fancy: string(DozenStandard),
+	aliases: []string{
+		"dozens",
+	},
+	conversion: exactFactor("12"),
+}
+
+var Thousand = CountUnit{
+	unit:  ThousandStandard,
+	full:  "thousand",
+	fancy: string(ThousandStandard),
+	aliases: []string{
+		"thousand seeds",
+		"kseeds",
+		"k seeds",
+	},
+	conversion: exactFactor("1000"),
+}
+
+// countUnitFromString returns the first count unit that matches s.
+func countUnitFromString(s string) (CountUnit, error) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(CountUnit); ok {
+			return u, nil
+		}
+	}
+	return CountUnit{}, fmt.Errorf("no count unit found for %s", s)
+}
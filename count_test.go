@@ -0,0 +1,50 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_countUnitFromString(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		argList  []string
+		wantUnit CountUnit
+		wantErr  bool
+	}{
+		"count": {
+			argList:  []string{"count", "each", "ea", "seeds", "Plants"},
+			wantUnit: Each,
+			wantErr:  false,
+		},
+		"dozen": {
+			argList:  []string{"doz", "dozen", "dozens"},
+			wantUnit: Dozen,
+			wantErr:  false,
+		},
+		"thousand": {
+			argList:  []string{"thousand", "thousand seeds", "kseeds"},
+			wantUnit: Thousand,
+			wantErr:  false,
+		},
+		"no match": {
+			argList:  []string{"k", "gross", "dz"},
+			wantUnit: CountUnit{},
+			wantErr:  true,
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, arg := range c.argList {
+				gotUnit, err := countUnitFromString(arg)
+				assert.Equal(t, c.wantErr, err != nil)
+				assert.Equal(t, c.wantUnit, gotUnit)
+			}
+		})
+	}
+}
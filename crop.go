@@ -1,8 +1,8 @@
 package convert
 
 import (
-	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -28,98 +28,112 @@ const (
 	Wheat    Crop = "wheat"
 )
 
-// bushelCrops are crops for which harvest can be recorded in bushels.
-var bushelCrops = []Crop{
-	Alfalfa,
-	Barley,
-	Corn,
-	Flax,
-	Lucerne,
-	Maize,
-	Millet,
-	Oats,
-	Rye,
-	Sorghum,
-	Soybean,
-	Soybeans,
-	Spelt,
-	Wheat,
+// cropBushelsToGrams provides a factor for converting from 1 Bushel of the specified crop, To grams. These are the
+// bushel weights of factor set v1.
+// Bushel weights are defined in pounds, so the factors are exact multiples of the international pound (453.59237 g).
+var cropBushelsToGrams = map[Crop]factor{
+	Alfalfa:  exactFactor("27215.5422").withSource(usdaBushelWeights),  // 60 lb
+	Barley:   exactFactor("21772.43376").withSource(usdaBushelWeights), // 48 lb
+	Corn:     exactFactor("25401.17272").withSource(usdaBushelWeights), // 56 lb
+	Flax:     exactFactor("25401.17272").withSource(usdaBushelWeights), // 56 lb
+	Lucerne:  exactFactor("27215.5422").withSource(usdaBushelWeights),  // 60 lb
+	Maize:    exactFactor("25401.17272").withSource(usdaBushelWeights), // 56 lb
+	Millet:   exactFactor("22679.6185").withSource(usdaBushelWeights),  // 50 lb
+	Oats:     exactFactor("14514.95584").withSource(usdaBushelWeights), // US (32lb), Canada is 15.4221 (34lb)
+	Rye:      exactFactor("25401.17272").withSource(usdaBushelWeights), // 56 lb
+	Sorghum:  exactFactor("25401.17272").withSource(usdaBushelWeights), // 56 lb
+	Soybean:  exactFactor("27215.5422").withSource(usdaBushelWeights),  // 60 lb
+	Soybeans: exactFactor("27215.5422").withSource(usdaBushelWeights),  // 60 lb
+	Spelt:    exactFactor("18143.6948").withSource(usdaBushelWeights),  // 40 lb
+	Wheat:    exactFactor("27215.5422").withSource(usdaBushelWeights),  // 60 lb
 }
 
-// baleCrops are crops for which harvest can be recorded in bales.
-var baleCrops = []Crop{
-	Cotton,
+// cropBalesToGrams provides a factor for converting from 1 Bale of the specified crop, To grams. These are the bale
+// weights of factor set v1.
+// Only cotton for now but may also be applicable To hay and similar.
+var cropBalesToGrams = map[Crop]factor{
+	Cotton: exactFactor("226796.185").withSource(cottonBaleWeight), // 500 lb
 }
 
-// cropBushelsToGrams provides a factor for converting from 1 Bushel of the specified crop, To grams.
-var cropBushelsToGrams = map[Crop]float64{
-	Alfalfa:  27215.5,
-	Barley:   21772,
-	Corn:     25400,
-	Flax:     25401.2,
-	Lucerne:  27215.5,
-	Maize:    25400,
-	Millet:   22679.6,
-	Oats:     14515, // US (32lb), Canada is 15.4221 (34lb)
-	Rye:      25401.2,
-	Sorghum:  25400,
-	Soybean:  27215.5,
-	Soybeans: 27215.5,
-	Spelt:    18143.7,
-	Wheat:    27215.5,
+// baselineBushelsToGrams are the bushel weights of factor set v0, which were rounded from the pound weights.
+var baselineBushelsToGrams = map[Crop]factor{
+	Alfalfa:  exactFactor("27215.5").withSource(packageBaseline),
+	Barley:   exactFactor("21772").withSource(packageBaseline),
+	Corn:     exactFactor("25400").withSource(packageBaseline),
+	Flax:     exactFactor("25401.2").withSource(packageBaseline),
+	Lucerne:  exactFactor("27215.5").withSource(packageBaseline),
+	Maize:    exactFactor("25400").withSource(packageBaseline),
+	Millet:   exactFactor("22679.6").withSource(packageBaseline),
+	Oats:     exactFactor("14515").withSource(packageBaseline),
+	Rye:      exactFactor("25401.2").withSource(packageBaseline),
+	Sorghum:  exactFactor("25400").withSource(packageBaseline),
+	Soybean:  exactFactor("27215.5").withSource(packageBaseline),
+	Soybeans: exactFactor("27215.5").withSource(packageBaseline),
+	Spelt:    exactFactor("18143.7").withSource(packageBaseline),
+	Wheat:    exactFactor("27215.5").withSource(packageBaseline),
 }
 
-// cropBalesToGrams provides a factor for converting from 1 Bale of the specified crop, To grams.
-// Only cotton for now but may also be applicable To hay and similar.
-var cropBalesToGrams = map[Crop]float64{
-	Cotton: 226800, // ref: https://en.wikipedia.org/wiki/Cotton_bale (500lb)
+// baselineBalesToGrams are the bale weights of factor set v0.
+var baselineBalesToGrams = map[Crop]factor{
+	Cotton: exactFactor("226800").withSource(packageBaseline),
 }
 
-func isBushelCrop(s string) bool {
-	for _, u := range bushelCrops {
-		if strings.EqualFold(string(u), s) {
-			return true
-		}
-	}
-	return false
+// isBushelCrop returns true if the factor set has a bushel weight for the crop, which is case-insensitive.
+func (s FactorSet) isBushelCrop(crop string) bool {
+	_, ok := s.bushelWeights[Crop(strings.ToLower(crop))]
+	return ok
 }
 
-func isBaleCrop(s string) bool {
-	for _, u := range baleCrops {
-		if strings.EqualFold(string(u), s) {
-			return true
-		}
+// isBaleCrop returns true if the factor set has a bale weight for the crop, which is case-insensitive.
+func (s FactorSet) isBaleCrop(crop string) bool {
+	_, ok := s.baleWeights[Crop(strings.ToLower(crop))]
+	return ok
+}
+
+// unknownCropError returns the error for a crop that is not one of the bushel or bale crops of the factor set.
+func (s FactorSet) unknownCropError(crop string) error {
+	return &UnknownCropError{Crop: crop, BushelCrops: sortedCrops(s.bushelWeights), BaleCrops: sortedCrops(s.baleWeights)}
+}
+
+// sortedCrops returns the crops of the weights, sorted by name.
+func sortedCrops(weights map[Crop]factor) []Crop {
+	crops := make([]Crop, 0, len(weights))
+	for c := range weights {
+		crops = append(crops, c)
 	}
-	return false
+	sort.Slice(crops, func(i, j int) bool {
+		return crops[i] < crops[j]
+	})
+	return crops
 }
 
 // bushelsToGrams converts the given number of crop bushels To grams.
-func bushelsToGrams(bushels float64, crop Crop) (MassMeasurement, error) {
-	f, ok := cropBushelsToGrams[crop]
+func (s FactorSet) bushelsToGrams(bushels float64, crop Crop) (MassMeasurement, error) {
+	f, ok := s.bushelWeights[crop]
 	if !ok {
 		return MassMeasurement{}, fmt.Errorf("cannot convert bushels To grams for %s", crop)
 	}
 	return MassMeasurement{
-		Value: bushels * f,
+		Value: bushels * f.value,
 		Unit:  Gram,
 	}, nil
 }
 
 // cropGramsToBushels coverts grams To crop bushels.
-func cropGramsToBushels(crop Crop, grams float64) (VolumeMeasurement, error) {
-	f, ok := cropBushelsToGrams[crop]
+func (s FactorSet) cropGramsToBushels(crop Crop, grams float64) (VolumeMeasurement, error) {
+	f, ok := s.bushelWeights[crop]
 	if !ok {
 		return VolumeMeasurement{}, fmt.Errorf("cannot convert grams To bushels for %s", crop)
 	}
 	return VolumeMeasurement{
-		Value: grams * (1 / f),
-		Unit:  Bushel,
+		Value: grams / f.value,
+		Unit:  s.pin(Bushel).(VolumeUnit),
 	}, nil
 }
 
 // cropBushelsToMass converts the given number of crop bushels To the specified MassUnit.
-func cropBushelsToMass(crop Crop, bushels float64, unit MassUnit) (MassMeasurement, error) {
-	m, err := bushelsToGrams(bushels, crop)
+func (s FactorSet) cropBushelsToMass(crop Crop, bushels float64, unit MassUnit) (MassMeasurement, error) {
+	m, err := s.bushelsToGrams(bushels, crop)
 	if err != nil {
 		return MassMeasurement{}, err
 	}
@@ -127,38 +141,38 @@ func cropBushelsToMass(crop Crop, bushels float64, unit MassUnit) (MassMeasureme
 }
 
 // cropMassToBushels converts the given crop MassMeasurement To bushels.
-func cropMassToBushels(crop Crop, cropMass MassMeasurement) (VolumeMeasurement, error) {
+func (s FactorSet) cropMassToBushels(crop Crop, cropMass MassMeasurement) (VolumeMeasurement, error) {
 	m := cropMass.To(Gram)
-	return cropGramsToBushels(crop, m.Value)
+	return s.cropGramsToBushels(crop, m.Value)
 }
 
 // balesToGrams converts the given number of crop bales To grams.
-func balesToGrams(bales float64, crop Crop) (MassMeasurement, error) {
-	f, ok := cropBalesToGrams[crop]
+func (s FactorSet) balesToGrams(bales float64, crop Crop) (MassMeasurement, error) {
+	f, ok := s.baleWeights[crop]
 	if !ok {
 		return MassMeasurement{}, fmt.Errorf("cannot convert bales To grams for %s", crop)
 	}
 	return MassMeasurement{
-		Value: bales * f,
+		Value: bales * f.value,
 		Unit:  Gram,
 	}, nil
 }
 
 // cropGramsToBales coverts grams To crop bales.
-func cropGramsToBales(crop Crop, grams float64) (VolumeMeasurement, error) {
-	f, ok := cropBalesToGrams[crop]
+func (s FactorSet) cropGramsToBales(crop Crop, grams float64) (VolumeMeasurement, error) {
+	f, ok := s.baleWeights[crop]
 	if !ok {
 		return VolumeMeasurement{}, fmt.Errorf("cannot convert grams To bales for %s", crop)
 	}
 	return VolumeMeasurement{
-		Value: grams * (1 / f),
-		Unit:  Bale,
+		Value: grams / f.value,
+		Unit:  s.pin(Bale).(VolumeUnit),
 	}, nil
 }
 
 // cropBalesToMass converts the given number of crop bales To the specified MassUnit.
-func cropBalesToMass(crop Crop, bales float64, unit MassUnit) (MassMeasurement, error) {
-	m, err := balesToGrams(bales, crop)
+func (s FactorSet) cropBalesToMass(crop Crop, bales float64, unit MassUnit) (MassMeasurement, error) {
+	m, err := s.balesToGrams(bales, crop)
 	if err != nil {
 		return MassMeasurement{}, err
 	}
@@ -166,19 +180,16 @@ func cropBalesToMass(crop Crop, bales float64, unit MassUnit) (MassMeasurement,
 }
 
 // cropMassToBales converts the given crop MassMeasurement To bales.
-func cropMassToBales(crop Crop, cropMass MassMeasurement) (VolumeMeasurement, error) {
+func (s FactorSet) cropMassToBales(crop Crop, cropMass MassMeasurement) (VolumeMeasurement, error) {
 	m := cropMass.To(Gram)
-	return cropGramsToBales(crop, m.Value)
+	return s.cropGramsToBales(crop, m.Value)
 }
 
 // convertCropRate handles conversion between MassMeasurement and volume for crops whose yield
 // can be measured in either bushels or bales.
-func convertCropRate(crop string, value float64, fromUnit, toUnit string) (float64, error) {
-	if crop == "" {
-		return 0, errors.New("crop cannot be nil")
-	}
-	if !isBushelCrop(crop) && !isBaleCrop(crop) {
-		return 0, fmt.Errorf("crop must be one of the bushel crops %v, or a bale crop %v", bushelCrops, baleCrops)
+func (s FactorSet) convertCropRate(crop string, value float64, fromUnit, toUnit string) (float64, error) {
+	if !s.isBushelCrop(crop) && !s.isBaleCrop(crop) {
+		return 0, s.unknownCropError(crop)
 	}
 
 	// Get the units
@@ -193,12 +204,16 @@ func convertCropRate(crop string, value float64, fromUnit, toUnit string) (float
 
 	fromAreaUnit, err := areaUnitFromString(fromDenominator)
 	if err != nil {
-		return 0, fmt.Errorf("fromUnit %s denominator is not an AreaUnit: %w", fromUnit, err)
+		return 0, fmt.Errorf("fromUnit %s denominator is not an AreaUnit: %w", fromUnit,
+			dimensionError(fromDenominator, AreaDimension))
 	}
 	toAreaUnit, err := areaUnitFromString(toDenominator)
 	if err != nil {
-		return 0, fmt.Errorf("toUnit %s denominator is not an AreaUnit: %w", toUnit, err)
+		return 0, fmt.Errorf("toUnit %s denominator is not an AreaUnit: %w", toUnit,
+			dimensionError(toDenominator, AreaDimension))
 	}
+	fromAreaUnit = s.pin(fromAreaUnit).(AreaUnit)
+	toAreaUnit = s.pin(toAreaUnit).(AreaUnit)
 
 	// Mass -> Volume
 	if IsMassAreaRatioUnit(fromUnit) {
@@ -206,15 +221,16 @@ func convertCropRate(crop string, value float64, fromUnit, toUnit string) (float
 		if err != nil {
 			return 0, fmt.Errorf("could not create MassAreaMeasurement Value: %w", err)
 		}
+		massRate.MassMeasurement.Unit = s.pin(massRate.MassMeasurement.Unit).(MassUnit)
 		var cropVol VolumeMeasurement
-		if isBushelCrop(crop) {
-			cropVol, err = cropMassToBushels(Crop(crop), massRate.MassMeasurement)
+		if s.isBushelCrop(crop) {
+			cropVol, err = s.cropMassToBushels(Crop(crop), massRate.MassMeasurement)
 			if err != nil {
 				return 0, fmt.Errorf("could not convert crop MassMeasurement To bushels: %w", err)
 			}
 		}
-		if isBaleCrop(crop) {
-			cropVol, err = cropMassToBales(Crop(crop), massRate.MassMeasurement)
+		if s.isBaleCrop(crop) {
+			cropVol, err = s.cropMassToBales(Crop(crop), massRate.MassMeasurement)
 			if err != nil {
 				return 0, fmt.Errorf("could not convert crop MassMeasurement To bales: %w", err)
 			}
@@ -222,8 +238,10 @@ func convertCropRate(crop string, value float64, fromUnit, toUnit string) (float
 
 		toVolumeUnit, err := volumeUnitFromString(toNumerator)
 		if err != nil {
-			return 0, fmt.Errorf("toUnit %s numerator is not a VolumeUnit: %w", toUnit, err)
+			return 0, fmt.Errorf("toUnit %s numerator is not a VolumeUnit: %w", toUnit,
+				dimensionError(toNumerator, VolumeDimension))
 		}
+		toVolumeUnit = s.pin(toVolumeUnit).(VolumeUnit)
 		volRate := NewVolumeAreaMeasurement(cropVol.Value, cropVol.Unit, fromAreaUnit)
 		toRate := volRate.To(toVolumeUnit, toAreaUnit)
 		return toRate.Value(), nil
@@ -234,22 +252,25 @@ func convertCropRate(crop string, value float64, fromUnit, toUnit string) (float
 	if err != nil {
 		return 0, fmt.Errorf("could not create VolumeAreaMeasurement Value: %w", err)
 	}
+	vr = NewVolumeAreaMeasurement(vr.Value(), s.pin(vr.VolumeMeasurement.Unit).(VolumeUnit), s.pin(vr.AreaUnit).(AreaUnit))
 	toMassUnit, err := massUnitFromString(toNumerator)
 	if err != nil {
-		return 0, fmt.Errorf("toUnit %s numerator is not a MassUnit: %w", toUnit, err)
+		return 0, fmt.Errorf("toUnit %s numerator is not a MassUnit: %w", toUnit,
+			dimensionError(toNumerator, MassDimension))
 	}
+	toMassUnit = s.pin(toMassUnit).(MassUnit)
 
 	var cropMass MassMeasurement
-	if isBushelCrop(crop) {
-		vRate := vr.To(Bushel, fromAreaUnit) // keep original AreaUnit
-		cropMass, err = cropBushelsToMass(Crop(crop), vRate.Value(), toMassUnit)
+	if s.isBushelCrop(crop) {
+		vRate := vr.To(s.pin(Bushel).(VolumeUnit), fromAreaUnit) // keep original AreaUnit
+		cropMass, err = s.cropBushelsToMass(Crop(crop), vRate.Value(), toMassUnit)
 		if err != nil {
 			return 0, fmt.Errorf("could not convert crop bushels To MassMeasurement: %w", err)
 		}
 	}
-	if isBaleCrop(crop) {
-		vRate := vr.To(Bale, fromAreaUnit) // keep original AreaUnit
-		cropMass, err = cropBalesToMass(Crop(crop), vRate.Value(), toMassUnit)
+	if s.isBaleCrop(crop) {
+		vRate := vr.To(s.pin(Bale).(VolumeUnit), fromAreaUnit) // keep original AreaUnit
+		cropMass, err = s.cropBalesToMass(Crop(crop), vRate.Value(), toMassUnit)
 		if err != nil {
 			return 0, fmt.Errorf("could not convert crop bales To MassMeasurement: %w", err)
 		}
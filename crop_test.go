@@ -40,7 +40,7 @@ func Test_bushelsToGrams(t *testing.T) {
 		c := c
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			got, err := bushelsToGrams(c.bushels, c.crop)
+			got, err := DefaultFactorSet().bushelsToGrams(c.bushels, c.crop)
 			assert.NoError(t, err)
 			assert.InDelta(t, c.want.Value, got.Value, tolerance)
 			assert.Equal(t, c.want.Unit, got.Unit)
@@ -57,7 +57,7 @@ func Test_balesToGrams(t *testing.T) {
 		bales float64
 		want  MassMeasurement
 	}{
-		{name: "cotton", crop: "cotton", bales: 1.0, want: MassMeasurement{226800, Gram}},
+		{name: "cotton", crop: "cotton", bales: 1.0, want: MassMeasurement{226796.185, Gram}},
 	}
 
 	const tolerance = 0.001
@@ -65,7 +65,7 @@ func Test_balesToGrams(t *testing.T) {
 		c := c
 		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := balesToGrams(c.bales, c.crop)
+			got, err := DefaultFactorSet().balesToGrams(c.bales, c.crop)
 			assert.NoError(t, err)
 			assert.InDelta(t, c.want.Value, got.Value, tolerance)
 			assert.Equal(t, c.want.Unit, got.Unit)
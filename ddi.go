@@ -0,0 +1,161 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// DDI is an ISO 11783-11 (ISOBUS) Data Dictionary Identifier, which identifies a process data variable reported by a
+// task controller, such as DDI 2, Actual Volume Per Area Application Rate.
+type DDI uint16
+
+// String returns the DDI number, eg "DDI 2".
+func (d DDI) String() string {
+	return fmt.Sprintf("DDI %d", uint16(d))
+}
+
+// DDIDefinition is an entry in the ISOBUS data dictionary. A raw process data value is an integer count of
+// Resolution in Unit, eg a raw value of 10000 for DDI 2 is 100 mm³/m², which is 1 l/ha.
+type DDIDefinition struct {
+	DDI  DDI
+	Name string
+	Unit Unit
+
+	resolution factor
+}
+
+// Resolution returns the value of one raw count in the unit of the DDI.
+func (d DDIDefinition) Resolution() float64 {
+	return d.resolution.value
+}
+
+// The units of the ISOBUS data dictionary. A cubic millimetre is a microlitre.
+var (
+	ddiVolumePerArea = VolumeAreaRatioUnit{Numerator: Microlitre, Denominator: SquareMetre}
+	ddiMassPerArea   = MassAreaRatioUnit{Numerator: Milligram, Denominator: SquareMetre}
+	ddiCountPerArea  = CompoundUnit{Terms: []UnitPower{{Unit: Each, Exponent: 1}, {Unit: SquareMetre, Exponent: -1}}}
+	ddiVolumePerTime = CompoundUnit{Terms: []UnitPower{{Unit: Microlitre, Exponent: 1}, {Unit: Second, Exponent: -1}}}
+	ddiMassPerTime   = CompoundUnit{Terms: []UnitPower{{Unit: Milligram, Exponent: 1}, {Unit: Second, Exponent: -1}}}
+	ddiSpeed         = CompoundUnit{Terms: []UnitPower{{Unit: Millimetre, Exponent: 1}, {Unit: Second, Exponent: -1}}}
+)
+
+// ddiCatalogue is the application, yield, speed and moisture DDIs, ref: https://www.isobus.net/isobus/dDEntity
+var ddiCatalogue = []DDIDefinition{
+	{DDI: 1, Name: "Setpoint Volume Per Area Application Rate", Unit: ddiVolumePerArea, resolution: exactFactor("0.01")},
+	{DDI: 2, Name: "Actual Volume Per Area Application Rate", Unit: ddiVolumePerArea, resolution: exactFactor("0.01")},
+	{DDI: 3, Name: "Default Volume Per Area Application Rate", Unit: ddiVolumePerArea, resolution: exactFactor("0.01")},
+	{DDI: 4, Name: "Minimum Volume Per Area Application Rate", Unit: ddiVolumePerArea, resolution: exactFactor("0.01")},
+	{DDI: 5, Name: "Maximum Volume Per Area Application Rate", Unit: ddiVolumePerArea, resolution: exactFactor("0.01")},
+	{DDI: 6, Name: "Setpoint Mass Per Area Application Rate", Unit: ddiMassPerArea, resolution: exactFactor("1")},
+	{DDI: 7, Name: "Actual Mass Per Area Application Rate", Unit: ddiMassPerArea, resolution: exactFactor("1")},
+	{DDI: 8, Name: "Default Mass Per Area Application Rate", Unit: ddiMassPerArea, resolution: exactFactor("1")},
+	{DDI: 9, Name: "Minimum Mass Per Area Application Rate", Unit: ddiMassPerArea, resolution: exactFactor("1")},
+	{DDI: 10, Name: "Maximum Mass Per Area Application Rate", Unit: ddiMassPerArea, resolution: exactFactor("1")},
+	{DDI: 11, Name: "Setpoint Count Per Area Application Rate", Unit: ddiCountPerArea, resolution: exactFactor("0.001")},
+	{DDI: 12, Name: "Actual Count Per Area Application Rate", Unit: ddiCountPerArea, resolution: exactFactor("0.001")},
+	{DDI: 36, Name: "Setpoint Volume Per Time Application Rate", Unit: ddiVolumePerTime, resolution: exactFactor("1")},
+	{DDI: 37, Name: "Actual Volume Per Time Application Rate", Unit: ddiVolumePerTime, resolution: exactFactor("1")},
+	{DDI: 41, Name: "Setpoint Mass Per Time Application Rate", Unit: ddiMassPerTime, resolution: exactFactor("1")},
+	{DDI: 42, Name: "Actual Mass Per Time Application Rate", Unit: ddiMassPerTime, resolution: exactFactor("1")},
+	{DDI: 83, Name: "Actual Volume Per Area Yield", Unit: ddiVolumePerArea, resolution: exactFactor("0.01")},
+	{DDI: 84, Name: "Actual Mass Per Area Yield", Unit: ddiMassPerArea, resolution: exactFactor("1")},
+	{DDI: 85, Name: "Actual Count Per Area Yield", Unit: ddiCountPerArea, resolution: exactFactor("0.001")},
+	{DDI: 89, Name: "Actual Volume Per Time Yield", Unit: ddiVolumePerTime, resolution: exactFactor("1")},
+	{DDI: 90, Name: "Actual Mass Per Time Yield", Unit: ddiMassPerTime, resolution: exactFactor("1")},
+	{DDI: 99, Name: "Actual Harvest Moisture", Unit: PartsPerMillion, resolution: exactFactor("1")},
+	{DDI: 397, Name: "Actual Speed", Unit: ddiSpeed, resolution: exactFactor("1")},
+}
+
+// DDIs returns the DDIs in the catalogue, in DDI order.
+func DDIs() []DDIDefinition {
+	return append([]DDIDefinition(nil), ddiCatalogue...)
+}
+
+// LookupDDI returns the definition of the DDI, or an error if it is not in the catalogue.
+func LookupDDI(d DDI) (DDIDefinition, error) {
+	for _, def := range ddiCatalogue {
+		if def.DDI == d {
+			return def, nil
+		}
+	}
+	return DDIDefinition{}, fmt.Errorf("%s is not in the DDI catalogue", d)
+}
+
+// ToValue converts a raw process data value to a value in the unit, which can be any unit with the same dimension as
+// the unit of the DDI, eg l/ha or gal/ac for DDI 2.
+func (d DDIDefinition) ToValue(raw int32, unit string) (float64, error) {
+	return ValueFromTo(d.value(raw), d.Unit.String(), unit)
+}
+
+// FromValue converts a value in the unit to a raw process data value, rounded to the nearest count. It returns an
+// error if the value is out of the range of a process data value.
+func (d DDIDefinition) FromValue(value float64, unit string) (int32, error) {
+	v, err := ValueFromTo(value, unit, d.Unit.String())
+	if err != nil {
+		return 0, err
+	}
+	return d.rawValue(v)
+}
+
+// VolumePerArea returns a raw process data value of a volume per area DDI, such as DDI 2, as a
+// VolumeAreaRatioMeasurement in mm³/m², which can then be converted with To.
+func (d DDIDefinition) VolumePerArea(raw int32) (VolumeAreaRatioMeasurement, error) {
+	u, ok := d.Unit.(VolumeAreaRatioUnit)
+	if !ok {
+		return VolumeAreaRatioMeasurement{}, d.dimensionError(VolumeAreaRatioDimension)
+	}
+	return NewVolumeAreaMeasurement(d.value(raw), u.Numerator, u.Denominator), nil
+}
+
+// MassPerArea returns a raw process data value of a mass per area DDI, such as DDI 7, as a MassAreaRatioMeasure in
+// mg/m², which can then be converted with To.
+func (d DDIDefinition) MassPerArea(raw int32) (MassAreaRatioMeasure, error) {
+	u, ok := d.Unit.(MassAreaRatioUnit)
+	if !ok {
+		return MassAreaRatioMeasure{}, d.dimensionError(MassAreaRatioDimension)
+	}
+	return NewMassAreaRatioMeasure(d.value(raw), u.Numerator, u.Denominator), nil
+}
+
+// RawFromVolumePerArea returns the raw process data value of a volume per area DDI for the measurement.
+func (d DDIDefinition) RawFromVolumePerArea(m VolumeAreaRatioMeasurement) (int32, error) {
+	u, ok := d.Unit.(VolumeAreaRatioUnit)
+	if !ok {
+		return 0, d.dimensionError(VolumeAreaRatioDimension)
+	}
+	return d.rawValue(m.To(u.Numerator, u.Denominator).Value())
+}
+
+// RawFromMassPerArea returns the raw process data value of a mass per area DDI for the measurement.
+func (d DDIDefinition) RawFromMassPerArea(m MassAreaRatioMeasure) (int32, error) {
+	u, ok := d.Unit.(MassAreaRatioUnit)
+	if !ok {
+		return 0, d.dimensionError(MassAreaRatioDimension)
+	}
+	return d.rawValue(m.To(u.Numerator, u.Denominator).Value())
+}
+
+// value returns the raw process data value in the unit of the DDI.
+func (d DDIDefinition) value(raw int32) float64 {
+	v, _ := new(big.Rat).Mul(big.NewRat(int64(raw), 1), d.resolution.exact).Float64()
+	return v
+}
+
+// rawValue returns the value in the unit of the DDI as a raw process data value.
+func (d DDIDefinition) rawValue(v float64) (int32, error) {
+	raw := math.Round(v / d.resolution.value)
+	if math.IsNaN(raw) || raw < math.MinInt32 || raw > math.MaxInt32 {
+		return 0, fmt.Errorf("value %g %s is out of range for %s", v, d.Unit, d.DDI)
+	}
+	return int32(raw), nil
+}
+
+// dimensionError returns an error for a DDI that does not have the dimension want.
+func (d DDIDefinition) dimensionError(want Dimension) error {
+	return fmt.Errorf("%s %s: %w", d.DDI, d.Name, &IncompatibleDimensionsError{
+		From:          d.Unit.String(),
+		FromDimension: DimensionOf(d.Unit),
+		ToDimension:   want,
+	})
+}
@@ -0,0 +1,100 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDDIDefinition_ToValue(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		ddi  DDI
+		raw  int32
+		unit string
+		want float64
+	}{
+		"volume rate":    {ddi: 2, raw: 1000000, unit: "l/ha", want: 100},
+		"us volume rate": {ddi: 1, raw: 1000000, unit: "gal/ac", want: 10.6907},
+		"mass rate":      {ddi: 7, raw: 25000, unit: "kg/ha", want: 250},
+		"seed rate":      {ddi: 12, raw: 8000000, unit: "seeds/ha", want: 80000000},
+		"yield":          {ddi: 84, raw: 1000000, unit: "t/ha", want: 10},
+		"yield us":       {ddi: 84, raw: 1000000, unit: "lb/ac", want: 8921.7912},
+		"mass flow":      {ddi: 90, raw: 2000000, unit: "kg/h", want: 7200},
+		"moisture":       {ddi: 99, raw: 155000, unit: "%", want: 15.5},
+		"speed":          {ddi: 397, raw: 2778, unit: "km/h", want: 10.0008},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d, err := LookupDDI(c.ddi)
+			assert.NoError(t, err)
+			got, err := d.ToValue(c.raw, c.unit)
+			assert.NoError(t, err)
+			assert.InDelta(t, c.want, got, 0.0001)
+
+			raw, err := d.FromValue(got, c.unit)
+			assert.NoError(t, err)
+			assert.Equal(t, c.raw, raw)
+		})
+	}
+}
+
+func TestDDIDefinition_Measurements(t *testing.T) {
+	t.Parallel()
+
+	d, err := LookupDDI(2)
+	assert.NoError(t, err)
+	vr, err := d.VolumePerArea(1000000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 100, vr.To(Litre, Hectare).Value(), 0.0001)
+	raw, err := d.RawFromVolumePerArea(NewVolumeAreaMeasurement(20, Gallon, Acre))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1870791), raw) // 187.0791 l/ha
+	_, err = d.MassPerArea(1)
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions))
+
+	d, err = LookupDDI(7)
+	assert.NoError(t, err)
+	mr, err := d.MassPerArea(25000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 250, mr.To(Kilogram, Hectare).Value(), 0.0001)
+	raw, err = d.RawFromMassPerArea(NewMassAreaRatioMeasure(100, Pound, Acre))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(11209), raw) // 112.0851 kg/ha
+	_, err = d.RawFromVolumePerArea(NewVolumeAreaMeasurement(1, Litre, Hectare))
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions))
+}
+
+func TestDDIDefinition_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := LookupDDI(65535)
+	assert.EqualError(t, err, "DDI 65535 is not in the DDI catalogue")
+
+	d, err := LookupDDI(2)
+	assert.NoError(t, err)
+	_, err = d.ToValue(1, "kg/ha")
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions), err)
+	_, err = d.FromValue(1e12, "l/ha")
+	assert.Error(t, err)
+}
+
+func TestDDIs(t *testing.T) {
+	t.Parallel()
+
+	xs := DDIs()
+	for i, d := range xs {
+		if i > 0 {
+			assert.Less(t, xs[i-1].DDI, d.DDI)
+		}
+		assert.NotEmpty(t, d.Name)
+		assert.Greater(t, d.Resolution(), 0.0)
+		_, err := UnitFromLabel(d.Unit.String())
+		assert.NoError(t, err, d.DDI.String())
+	}
+}
@@ -45,46 +45,52 @@ func (d *DilutedProductApplication) ApplicationRate() (float64, string, error) {
 	return p3, unit.String(), nil
 }
 
-// UnitCheck checks that the units make sense.
+// UnitCheck checks that the units make sense. It returns an error that matches ErrUnknownUnit if a unit label is not
+// known, or ErrIncompatibleDimensions if a unit does not have the expected dimension.
 func (d *DilutedProductApplication) UnitCheck() error {
 	var err error
 
 	d.areaUnit, err = areaUnitFromString(d.AreaUnitLabel)
 	if err != nil {
-		return fmt.Errorf("invalid area unit: %s", d.AreaUnitLabel)
+		return fmt.Errorf("invalid area unit: %s: %w", d.AreaUnitLabel, dimensionError(d.AreaUnitLabel, AreaDimension))
 	}
 
 	d.productUnit, err = UnitFromLabel(d.ProductUnitLabel)
 	if err != nil {
-		return fmt.Errorf("invalid product unit: %s", d.ProductUnitLabel)
+		return fmt.Errorf("invalid product unit: %w", err)
 	}
 	if !IsMassUnit(d.productUnit.String()) && !IsVolumeUnit(d.productUnit.String()) {
-		return fmt.Errorf("product unit %s is not a mass or volume unit", d.productUnit.String())
+		return fmt.Errorf("%w: product unit %s is not a mass or volume unit", ErrIncompatibleDimensions,
+			d.productUnit.String())
 	}
 
 	// CarrierSolventUnit and CarrierApplicationUnit both need to be the same type - ie, either mass or volume.
 	// Eg: 10g/kg dilution spread at 50kg/ha makes sense, but 10g/l dilution spread at 10kg/ha cannot be resolved without
 	// knowing density.
-	if !IsMassUnit(d.CarrierSolventUnitLabel) && !IsVolumeUnit(d.CarrierSolventUnitLabel) {
-		return fmt.Errorf("carrier (solvent) unit %s is not a mass or volume unit", d.CarrierSolventUnitLabel)
-	}
 	d.carrierSolventUnit, err = UnitFromLabel(d.CarrierSolventUnitLabel)
 	if err != nil {
-		return fmt.Errorf("invalid carrier (solvent) unit: %s", d.CarrierSolventUnitLabel)
+		return fmt.Errorf("invalid carrier (solvent) unit: %w", err)
 	}
-
-	if !IsMassUnit(d.CarrierApplicationUnitLabel) && !IsVolumeUnit(d.CarrierApplicationUnitLabel) {
-		return fmt.Errorf("carrier (application) unit %s is not a mass or volume unit", d.CarrierApplicationUnitLabel)
+	if !IsMassUnit(d.CarrierSolventUnitLabel) && !IsVolumeUnit(d.CarrierSolventUnitLabel) {
+		return fmt.Errorf("%w: carrier (solvent) unit %s is not a mass or volume unit", ErrIncompatibleDimensions,
+			d.CarrierSolventUnitLabel)
 	}
+
 	d.carrierApplicationUnit, err = UnitFromLabel(d.CarrierApplicationUnitLabel)
 	if err != nil {
-		return fmt.Errorf("invalid carrier (application) unit: %s", d.CarrierApplicationUnitLabel)
+		return fmt.Errorf("invalid carrier (application) unit: %w", err)
+	}
+	if !IsMassUnit(d.CarrierApplicationUnitLabel) && !IsVolumeUnit(d.CarrierApplicationUnitLabel) {
+		return fmt.Errorf("%w: carrier (application) unit %s is not a mass or volume unit", ErrIncompatibleDimensions,
+			d.CarrierApplicationUnitLabel)
 	}
 
 	// Final check is that the carrier (solvent) unit and the carrier (application) unit are the same type.
 	if IsMassUnit(d.CarrierSolventUnitLabel) && IsVolumeUnit(d.CarrierApplicationUnitLabel) ||
 		IsVolumeUnit(d.CarrierSolventUnitLabel) && IsMassUnit(d.CarrierApplicationUnitLabel) {
-		return fmt.Errorf("carrier (solvent) unit %s and carrier (application) unit %s need to both be mass or both be volume", d.CarrierSolventUnitLabel, d.CarrierApplicationUnitLabel)
+		return fmt.Errorf("carrier (solvent) unit %s and carrier (application) unit %s need to both be mass or both be volume: %w",
+			d.CarrierSolventUnitLabel, d.CarrierApplicationUnitLabel,
+			newIncompatibleDimensionsError(d.CarrierApplicationUnitLabel, d.CarrierSolventUnitLabel))
 	}
 
 	return nil
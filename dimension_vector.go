@@ -0,0 +1,223 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DimensionVector is the dimension of a unit expressed as the exponents of the base dimensions. For example, kg/m3
+// has Mass 1 and Length -3, and a dimensionless unit such as % has every exponent 0. Any two units with the same
+// dimension vector can be converted.
+type DimensionVector struct {
+	Length      int
+	Mass        int
+	Time        int
+	Temperature int
+	Amount      int
+	Count       int
+}
+
+// baseDimensionNames are the names of the base dimensions, in the order used for String.
+var baseDimensionNames = []string{"length", "mass", "time", "temperature", "amount", "count"}
+
+// exponents returns the exponents of the base dimensions in the order of baseDimensionNames.
+func (v DimensionVector) exponents() []int {
+	return []int{v.Length, v.Mass, v.Time, v.Temperature, v.Amount, v.Count}
+}
+
+// Mul returns the dimension of the product of units with dimensions v and o.
+func (v DimensionVector) Mul(o DimensionVector) DimensionVector {
+	return DimensionVector{
+		Length:      v.Length + o.Length,
+		Mass:        v.Mass + o.Mass,
+		Time:        v.Time + o.Time,
+		Temperature: v.Temperature + o.Temperature,
+		Amount:      v.Amount + o.Amount,
+		Count:       v.Count + o.Count,
+	}
+}
+
+// Div returns the dimension of the quotient of units with dimensions v and o.
+func (v DimensionVector) Div(o DimensionVector) DimensionVector {
+	return v.Mul(o.Pow(-1))
+}
+
+// Pow returns the dimension of a unit with dimension v raised to the power n.
+func (v DimensionVector) Pow(n int) DimensionVector {
+	return DimensionVector{
+		Length:      v.Length * n,
+		Mass:        v.Mass * n,
+		Time:        v.Time * n,
+		Temperature: v.Temperature * n,
+		Amount:      v.Amount * n,
+		Count:       v.Count * n,
+	}
+}
+
+// IsDimensionless returns true if every exponent is zero.
+func (v DimensionVector) IsDimensionless() bool {
+	return v == DimensionVector{}
+}
+
+// String returns the dimension as a product of base dimensions, eg "mass·length⁻³", or "dimensionless".
+func (v DimensionVector) String() string {
+	var xs []string
+	for i, e := range v.exponents() {
+		switch e {
+		case 0:
+		case 1:
+			xs = append(xs, baseDimensionNames[i])
+		default:
+			xs = append(xs, baseDimensionNames[i]+superscript(e))
+		}
+	}
+	if len(xs) == 0 {
+		return "dimensionless"
+	}
+	return strings.Join(xs, "·")
+}
+
+// DimensionVectorOf returns the dimension vector of the unit u.
+func DimensionVectorOf(u Unit) (DimensionVector, error) {
+	v, _, err := unitScale(u)
+	return v, err
+}
+
+// unitScale returns the dimension vector of u and the exact factor that converts a value in u to the coherent base
+// units, which are the metre, gram, second, kelvin, mole and count. Temperature offsets are not included.
+func unitScale(u Unit) (DimensionVector, *big.Rat, error) {
+	switch v := u.(type) {
+	case AreaUnit:
+		return DimensionVector{Length: 2}, v.conversion.exact, nil
+	case LineUnit:
+		return DimensionVector{Length: 1}, v.conversion.exact, nil
+	case MassUnit:
+		return DimensionVector{Mass: 1}, v.conversion.exact, nil
+	case TimeUnit:
+		return DimensionVector{Time: 1}, v.conversion.exact, nil
+	case VolumeUnit:
+		// Volume factors are in litres, which are 1/1000 m3
+		return DimensionVector{Length: 3}, new(big.Rat).Quo(v.conversion.exact, big.NewRat(1000, 1)), nil
+	case TemperatureUnit:
+		return DimensionVector{Temperature: 1}, v.conversion.exact, nil
+	case AmountUnit:
+		return DimensionVector{Amount: 1}, v.conversion.exact, nil
+	case CountUnit:
+		return DimensionVector{Count: 1}, v.conversion.exact, nil
+	case FractionUnit:
+		return DimensionVector{}, v.conversion.exact, nil
+	case MassAreaRatioUnit:
+		return quotientScale(v.Numerator, v.Denominator)
+	case VolumeAreaRatioUnit:
+		return quotientScale(v.Numerator, v.Denominator)
+	case RatioUnit:
+		return quotientScale(v.Numerator, v.Denominator)
+	case ScaledUnit:
+		d, f, err := unitScale(v.Unit)
+		if err != nil {
+			return DimensionVector{}, nil, err
+		}
+		return d, new(big.Rat).Mul(f, v.Scale), nil
+	case CompoundUnit:
+		d, f := DimensionVector{}, big.NewRat(1, 1)
+		for _, t := range v.Terms {
+			td, tf, err := unitScale(t.Unit)
+			if err != nil {
+				return DimensionVector{}, nil, err
+			}
+			d = d.Mul(td.Pow(t.Exponent))
+			f.Mul(f, ratPow(tf, t.Exponent))
+		}
+		return d, f, nil
+	}
+	return DimensionVector{}, nil, fmt.Errorf("no dimension for unit %v", u)
+}
+
+// quotientScale returns the dimension vector and factor of the ratio of two units.
+func quotientScale(numerator, denominator Unit) (DimensionVector, *big.Rat, error) {
+	nd, nf, err := unitScale(numerator)
+	if err != nil {
+		return DimensionVector{}, nil, err
+	}
+	dd, df, err := unitScale(denominator)
+	if err != nil {
+		return DimensionVector{}, nil, err
+	}
+	return nd.Div(dd), new(big.Rat).Quo(nf, df), nil
+}
+
+// ratPow returns r raised to the integer power n.
+func ratPow(r *big.Rat, n int) *big.Rat {
+	p := big.NewRat(1, 1)
+	for i := 0; i < n; i++ {
+		p.Mul(p, r)
+	}
+	for i := 0; i > n; i-- {
+		p.Quo(p, r)
+	}
+	return p
+}
+
+// convertUnits converts the value between any two units with the same dimension vector. Absolute temperatures are
+// converted with the offsets between the scales, and temperatures in compound units, such as degC/h, are treated as
+// temperature differences. The result is rounded once to float64 for finite values.
+func convertUnits(value float64, from, to Unit) (float64, error) {
+	r := new(big.Rat)
+	if r.SetFloat64(value) != nil {
+		exact, err := convertUnitsExact(r, from, to)
+		if err != nil {
+			return 0, err
+		}
+		f, _ := exact.Float64()
+		return f, nil
+	}
+	// Infinity and NaN have no exact representation
+	if tf, ok := from.(TemperatureUnit); ok {
+		if tt, ok := to.(TemperatureUnit); ok {
+			return convertTemperature(value, tf, tt), nil
+		}
+	}
+	f, err := conversionRatio(from, to)
+	if err != nil {
+		return 0, err
+	}
+	ff, _ := f.Float64()
+	return value * ff, nil
+}
+
+// convertUnitsExact is the exact equivalent of convertUnits.
+func convertUnitsExact(value *big.Rat, from, to Unit) (*big.Rat, error) {
+	if tf, ok := from.(TemperatureUnit); ok {
+		if tt, ok := to.(TemperatureUnit); ok {
+			return convertTemperatureExact(value, tf, tt), nil
+		}
+	}
+	f, err := conversionRatio(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return f.Mul(f, value), nil
+}
+
+// conversionRatio returns the exact number of 'to' units in one 'from' unit, or an *IncompatibleDimensionsError if
+// the units do not have the same dimension vector.
+func conversionRatio(from, to Unit) (*big.Rat, error) {
+	fd, ff, err := unitScale(from)
+	if err != nil {
+		return nil, err
+	}
+	td, tf, err := unitScale(to)
+	if err != nil {
+		return nil, err
+	}
+	if fd != td {
+		return nil, &IncompatibleDimensionsError{
+			From:          from.String(),
+			FromDimension: DimensionOf(from),
+			To:            to.String(),
+			ToDimension:   DimensionOf(to),
+		}
+	}
+	return new(big.Rat).Quo(ff, tf), nil
+}
@@ -0,0 +1,137 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDimensionVectorOf(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg        string
+		want       DimensionVector
+		wantString string
+	}{
+		"area":           {arg: "ha", want: DimensionVector{Length: 2}, wantString: "length²"},
+		"volume":         {arg: "l", want: DimensionVector{Length: 3}, wantString: "length³"},
+		"mass area":      {arg: "kg/ha", want: DimensionVector{Length: -2, Mass: 1}, wantString: "length⁻²·mass"},
+		"density":        {arg: "kg/m3", want: DimensionVector{Length: -3, Mass: 1}, wantString: "length⁻³·mass"},
+		"yield per year": {arg: "t/ha/yr", want: DimensionVector{Length: -2, Mass: 1, Time: -1}, wantString: "length⁻²·mass·time⁻¹"},
+		"temperature":    {arg: "degC", want: DimensionVector{Temperature: 1}, wantString: "temperature"},
+		"amount":         {arg: "mmol/l", want: DimensionVector{Length: -3, Amount: 1}, wantString: "length⁻³·amount"},
+		"seed rate":      {arg: "seeds/m2", want: DimensionVector{Length: -2, Count: 1}, wantString: "length⁻²·count"},
+		"dimensionless":  {arg: "%", want: DimensionVector{}, wantString: "dimensionless"},
+		"per second":     {arg: "1/s", want: DimensionVector{Time: -1}, wantString: "time⁻¹"},
+		"product":        {arg: "kg·m/s2", want: DimensionVector{Length: 1, Mass: 1, Time: -2}, wantString: "length·mass·time⁻²"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := UnitFromLabel(c.arg)
+			assert.NoError(t, err)
+			got, err := DimensionVectorOf(u)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+			assert.Equal(t, c.wantString, got.String())
+		})
+	}
+}
+
+func TestCompoundUnit(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		"slash form":       {arg: "kg/m3", want: "kg1[m3]-1"},
+		"chained slashes":  {arg: "t/ha/yr", want: "t1ha-1yr-1"},
+		"exponent form":    {arg: "t1ha-1yr-1", want: "t1ha-1yr-1"},
+		"bracketed":        {arg: "kg1[m3]-1", want: "kg1[m3]-1"},
+		"per form":         {arg: "kg per m3", want: "kg1[m3]-1"},
+		"caret exponent":   {arg: "kg/s^2", want: "kg1s-2"},
+		"product":          {arg: "kg*m/s2", want: "kg1m1s-2"},
+		"unknown term":     {arg: "kg/xx", wantErr: true},
+		"empty term":       {arg: "t//yr", wantErr: true},
+		"single unit":      {arg: "kg1", wantErr: true},
+		"unclosed bracket": {arg: "kg1[m3-1", wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseCompoundUnit(c.arg)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			if err == nil {
+				assert.Equal(t, c.want, got.String())
+			}
+		})
+	}
+}
+
+func TestValueFromTo_DimensionVector(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		"density":           {value: 1, from: "kg/m3", to: "g/l", want: 1},
+		"density scaled":    {value: 1000, from: "kg/m3", to: "t/m3", want: 1},
+		"yield per year":    {value: 1, from: "t/ha/yr", to: "kg/ha/d", want: 1000.0 / 365},
+		"exponent form":     {value: 2, from: "t1ha-1yr-1", to: "kg/ha/yr", want: 2000},
+		"celsius":           {value: 20, from: "degC", to: "degF", want: 68},
+		"kelvin":            {value: 0, from: "degC", to: "K", want: 273.15},
+		"temperature rate":  {value: 1, from: "degC/h", to: "K/min", want: 1.0 / 60},
+		"percent to ppm":    {value: 1.5, from: "%", to: "ppm", want: 15000},
+		"per mille":         {value: 2, from: "permille", to: "%", want: 0.2},
+		"fraction to ratio": {value: 25, from: "%", to: "g/kg", want: 250},
+		"time":              {value: 2, from: "h", to: "min", want: 120},
+		"amount":            {value: 1, from: "mol/l", to: "mmol/ml", want: 1},
+		"count":             {value: 2, from: "doz", to: "count", want: 24},
+		"seed rate":         {value: 100, from: "kseeds/ha", to: "seeds/m2", want: 10},
+		"speed":             {value: 36, from: "km/h", to: "m/s", want: 10},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ValueFromTo(c.value, c.from, c.to)
+			assert.NoError(t, err)
+			assert.InDelta(t, c.want, got, 1e-9)
+		})
+	}
+}
+
+func TestValueFromTo_DimensionVectorErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		from, to string
+		want     string
+	}{
+		"linear to area rate": {from: "kg/m", to: "kg/ha", want: "cannot convert from kg/m (length⁻¹·mass) to kg/ha (mass/area)"},
+		"temperature to kg":   {from: "degC", to: "kg", want: "cannot convert from degC (temperature) to kg (mass)"},
+		"count to amount":     {from: "count", to: "mol", want: "cannot convert from count (count) to mol (amount)"},
+		"percent to density":  {from: "%", to: "kg/m3", want: "cannot convert from % (dimensionless) to kg/m3 (dilution rate)"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ValueFromTo(1, c.from, c.to)
+			assert.True(t, errors.Is(err, ErrIncompatibleDimensions), err)
+			assert.EqualError(t, err, c.want)
+		})
+	}
+}
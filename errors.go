@@ -0,0 +1,229 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors that can be matched with errors.Is to tell the kind of a failure.
+var (
+	// ErrUnknownUnit is returned when a unit label is not a known unit.
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrAmbiguousUnit is returned when a unit label resolves to more than one unit.
+	ErrAmbiguousUnit = errors.New("ambiguous unit label")
+	// ErrIncompatibleDimensions is returned when units are known but cannot be converted or combined, eg kg and l.
+	ErrIncompatibleDimensions = errors.New("incompatible dimensions")
+	// ErrUnknownCrop is returned when a crop is required and is not one of the bushel or bale crops.
+	ErrUnknownCrop = errors.New("unknown crop")
+	// ErrMalformedCompoundUnit is returned when a compound unit label cannot be split into a numerator and
+	// denominator.
+	ErrMalformedCompoundUnit = errors.New("malformed compound unit")
+	// ErrUnmappedUCUM is returned when a UCUM unit has no equivalent in the package, or a unit has no UCUM code.
+	ErrUnmappedUCUM = errors.New("unmapped UCUM unit")
+	// ErrUnmappedQUDT is returned when a QUDT unit has no equivalent in the package, or a unit has no QUDT unit IRI
+	// or quantity kind.
+	ErrUnmappedQUDT = errors.New("unmapped QUDT unit")
+	// ErrUnmappedADAPT is returned when an ADAPT unit of measure code has no equivalent in the package, or a unit has
+	// no ADAPT code.
+	ErrUnmappedADAPT = errors.New("unmapped ADAPT unit")
+)
+
+// IncompatibleDimensionsError is returned when two units have dimensions that cannot be converted or combined.
+// If To is empty, the unit From does not have the dimension ToDimension.
+type IncompatibleDimensionsError struct {
+	From          string
+	FromDimension Dimension
+	To            string
+	ToDimension   Dimension
+}
+
+// newIncompatibleDimensionsError returns an IncompatibleDimensionsError for the unit labels from and to.
+func newIncompatibleDimensionsError(from, to string) *IncompatibleDimensionsError {
+	return &IncompatibleDimensionsError{
+		From:          from,
+		FromDimension: labelDimension(from),
+		To:            to,
+		ToDimension:   labelDimension(to),
+	}
+}
+
+// Error satisfies the error interface.
+func (e *IncompatibleDimensionsError) Error() string {
+	if e.To == "" {
+		return fmt.Sprintf("%s is %s, expecting %s", e.From, dimensionName(e.FromDimension), dimensionName(e.ToDimension))
+	}
+	return fmt.Sprintf("cannot convert from %s (%s) to %s (%s)", e.From, dimensionName(e.FromDimension), e.To,
+		dimensionName(e.ToDimension))
+}
+
+// Is allows errors.Is(err, ErrIncompatibleDimensions) to match an IncompatibleDimensionsError.
+func (e *IncompatibleDimensionsError) Is(target error) bool {
+	return target == ErrIncompatibleDimensions
+}
+
+// UnknownCropError is returned when a crop is not one of the bushel or bale crops of the factor set that was used.
+// BushelCrops and BaleCrops are the crops of that factor set, sorted by name.
+type UnknownCropError struct {
+	Crop        string
+	BushelCrops []Crop
+	BaleCrops   []Crop
+}
+
+// Error satisfies the error interface.
+func (e *UnknownCropError) Error() string {
+	if e.Crop == "" {
+		return fmt.Sprintf("crop cannot be empty, must be one of the bushel crops %v, or a bale crop %v", e.BushelCrops,
+			e.BaleCrops)
+	}
+	return fmt.Sprintf("unknown crop: %s, must be one of the bushel crops %v, or a bale crop %v", e.Crop, e.BushelCrops,
+		e.BaleCrops)
+}
+
+// Is allows errors.Is(err, ErrUnknownCrop) to match an UnknownCropError.
+func (e *UnknownCropError) Is(target error) bool {
+	return target == ErrUnknownCrop
+}
+
+// MalformedCompoundUnitError is returned when a compound unit label cannot be split into a numerator and
+// denominator. Position is the byte offset in Label where the problem was found.
+type MalformedCompoundUnitError struct {
+	Label    string
+	Position int
+	Reason   string
+}
+
+// Error satisfies the error interface.
+func (e *MalformedCompoundUnitError) Error() string {
+	return fmt.Sprintf("%s %s at position %d: %s", ErrMalformedCompoundUnit, e.Label, e.Position, e.Reason)
+}
+
+// Is allows errors.Is(err, ErrMalformedCompoundUnit) to match a MalformedCompoundUnitError.
+func (e *MalformedCompoundUnitError) Is(target error) bool {
+	return target == ErrMalformedCompoundUnit
+}
+
+// UnmappedUCUMError is returned when a UCUM expression has a unit with no equivalent in the package, or when a unit
+// has no UCUM code. When parsing, Code is the UCUM unit and Position is its byte offset in Input. When formatting,
+// Unit is the standard label of the unit.
+type UnmappedUCUMError struct {
+	Code     string
+	Input    string
+	Position int
+	Unit     string
+}
+
+// Error satisfies the error interface.
+func (e *UnmappedUCUMError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s: unit %s has no UCUM code", ErrUnmappedUCUM, e.Unit)
+	}
+	return fmt.Sprintf("%s %s at position %d in %s", ErrUnmappedUCUM, e.Code, e.Position, e.Input)
+}
+
+// Is allows errors.Is(err, ErrUnmappedUCUM) to match an UnmappedUCUMError.
+func (e *UnmappedUCUMError) Is(target error) bool {
+	return target == ErrUnmappedUCUM
+}
+
+// UnmappedQUDTError is returned when a QUDT unit IRI has a unit with no equivalent in the package, or when a unit
+// has no QUDT unit IRI or quantity kind. IRI is set when reading an IRI, and Unit when writing one.
+type UnmappedQUDTError struct {
+	IRI  string
+	Unit string
+}
+
+// Error satisfies the error interface.
+func (e *UnmappedQUDTError) Error() string {
+	if e.IRI == "" {
+		return fmt.Sprintf("%s: unit %s has no QUDT equivalent", ErrUnmappedQUDT, e.Unit)
+	}
+	return fmt.Sprintf("%s %s", ErrUnmappedQUDT, e.IRI)
+}
+
+// Is allows errors.Is(err, ErrUnmappedQUDT) to match an UnmappedQUDTError.
+func (e *UnmappedQUDTError) Is(target error) bool {
+	return target == ErrUnmappedQUDT
+}
+
+// UnmappedADAPTError is returned when an ADAPT unit of measure code has a unit with no equivalent in the package, or
+// when a unit has no ADAPT code. Code is set when reading a code, and Unit when writing one.
+type UnmappedADAPTError struct {
+	Code string
+	Unit string
+}
+
+// Error satisfies the error interface.
+func (e *UnmappedADAPTError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s: unit %s has no ADAPT code", ErrUnmappedADAPT, e.Unit)
+	}
+	if quantity, ok := adaptUnmappedCodes[e.Code]; ok {
+		return fmt.Sprintf("%s %s: %s is not supported", ErrUnmappedADAPT, e.Code, quantity)
+	}
+	return fmt.Sprintf("%s %s", ErrUnmappedADAPT, e.Code)
+}
+
+// Is allows errors.Is(err, ErrUnmappedADAPT) to match an UnmappedADAPTError.
+func (e *UnmappedADAPTError) Is(target error) bool {
+	return target == ErrUnmappedADAPT
+}
+
+// labelDimension returns the dimension of the unit label, or an empty Dimension if it is not a known unit.
+func labelDimension(label string) Dimension {
+	u, err := UnitFromLabel(label)
+	if err != nil {
+		return ""
+	}
+	return DimensionOf(u)
+}
+
+// dimensionName returns the name of the dimension for an error message.
+func dimensionName(d Dimension) string {
+	if d == "" {
+		return "unknown dimension"
+	}
+	return d.String()
+}
+
+// unitLabelError returns nil if the unit label is a known unit. Otherwise, it returns a MalformedCompoundUnitError
+// or an UnknownUnitError that identifies the part of the label that is not known, or an UnmappedUCUMError if the
+// label has a UCUM unit in square brackets, eg [acr_br], with no equivalent in the package, or an UnmappedQUDTError
+// if the label is a QUDT unit IRI with no equivalent, or an UnmappedADAPTError if the label is an ADAPT code of a
+// quantity that the package does not convert, eg kPa.
+func unitLabelError(label string) error {
+	if _, err := UnitFromLabel(label); err == nil {
+		return nil
+	}
+	if isQUDTUnitIRI(label) {
+		_, err := UnitFromQUDT(label)
+		return err
+	}
+	var ue *UnmappedUCUMError
+	if _, err := ParseUCUM(label); errors.As(err, &ue) && strings.HasPrefix(ue.Code, "[") {
+		return err
+	}
+	if _, ok := adaptUnmappedCodes[label]; ok {
+		return &UnmappedADAPTError{Code: label}
+	}
+	if _, err := ParseUnitExpression(label); err != nil {
+		var ue *UnknownUnitError
+		if !errors.As(err, &ue) || ue.Label != label {
+			return err
+		}
+	}
+	return &UnknownUnitError{Label: label}
+}
+
+// dimensionError returns an error for a unit label that does not have the dimension want. It is an UnknownUnitError
+// or a MalformedCompoundUnitError if the label is not a known unit, otherwise an IncompatibleDimensionsError.
+func dimensionError(label string, want Dimension) error {
+	if err := unitLabelError(label); err != nil {
+		return err
+	}
+	return &IncompatibleDimensionsError{
+		From:          label,
+		FromDimension: labelDimension(label),
+		ToDimension:   want,
+	}
+}
@@ -0,0 +1,160 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueFromTo_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		from, to string
+		want     error
+	}{
+		"unknown from unit":         {from: "xx", to: "kg", want: &UnknownUnitError{Label: "xx"}},
+		"unknown to unit":           {from: "kg", to: "yy", want: &UnknownUnitError{Label: "yy"}},
+		"unknown denominator":       {from: "kg/xx", to: "lb/ac", want: &UnknownUnitError{Label: "xx", Input: "kg/xx", Position: 3}},
+		"malformed compound":        {from: "kg//ha", to: "lb/ac", want: &MalformedCompoundUnitError{Label: "kg//ha", Position: 3, Reason: "expected a unit, found '/'"}},
+		"incompatible simple units": {from: "kg", to: "l", want: &IncompatibleDimensionsError{"kg", MassDimension, "l", VolumeDimension}},
+		"incompatible compounds":    {from: "kg/ha", to: "l/ha", want: &IncompatibleDimensionsError{"kg/ha", MassAreaRatioDimension, "l/ha", VolumeAreaRatioDimension}},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ValueFromTo(1, c.from, c.to)
+			assert.Equal(t, c.want.Error(), err.Error())
+			switch want := c.want.(type) {
+			case *UnknownUnitError:
+				var e *UnknownUnitError
+				assert.True(t, errors.As(err, &e))
+				assert.True(t, errors.Is(err, ErrUnknownUnit))
+				assert.Equal(t, want.Label, e.Label)
+				assert.Equal(t, want.Position, e.Position)
+			case *MalformedCompoundUnitError:
+				assert.Equal(t, want, err)
+				assert.True(t, errors.Is(err, ErrMalformedCompoundUnit))
+			case *IncompatibleDimensionsError:
+				assert.Equal(t, want, err)
+				assert.True(t, errors.Is(err, ErrIncompatibleDimensions))
+			}
+		})
+	}
+}
+
+func TestSplitCompoundUnit_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg          string
+		wantSentinel error
+		wantLabel    string
+		wantPosition int
+	}{
+		"no separator":                  {arg: "kgha", wantSentinel: ErrMalformedCompoundUnit, wantLabel: "kgha"},
+		"empty":                         {arg: "", wantSentinel: ErrMalformedCompoundUnit},
+		"too many slashes":              {arg: "lb//ac", wantSentinel: ErrMalformedCompoundUnit, wantLabel: "lb//ac", wantPosition: 3},
+		"missing numerator":             {arg: " /ha", wantSentinel: ErrMalformedCompoundUnit, wantLabel: " /ha", wantPosition: 1},
+		"missing denominator":           {arg: "kg/", wantSentinel: ErrMalformedCompoundUnit, wantLabel: "kg/", wantPosition: 3},
+		"unknown numerator":             {arg: "xx/ha", wantSentinel: ErrUnknownUnit, wantLabel: "xx"},
+		"unknown denominator with case": {arg: "kg / YY", wantSentinel: ErrUnknownUnit, wantLabel: "yy", wantPosition: 5},
+		"unknown bracketed denominator": {arg: "kg1[ha3]-1", wantSentinel: ErrUnknownUnit, wantLabel: "ha3", wantPosition: 4},
+		"unknown per form denominator":  {arg: "kg per xx", wantSentinel: ErrUnknownUnit, wantLabel: "xx", wantPosition: 7},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := SplitCompoundUnit(c.arg)
+			assert.True(t, errors.Is(err, c.wantSentinel), err)
+			var ue *UnknownUnitError
+			if errors.As(err, &ue) {
+				assert.Equal(t, c.wantLabel, ue.Label)
+				assert.Equal(t, c.arg, ue.Input)
+				assert.Equal(t, c.wantPosition, ue.Position)
+			}
+			var me *MalformedCompoundUnitError
+			if errors.As(err, &me) {
+				assert.Equal(t, c.wantLabel, me.Label)
+				assert.Equal(t, c.wantPosition, me.Position)
+			}
+		})
+	}
+}
+
+func TestCropRate_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		crop, from, to string
+		wantSentinel   error
+	}{
+		"unknown crop":                 {crop: "kale", from: "kg/ha", to: "bu/ac", wantSentinel: ErrUnknownCrop},
+		"empty crop":                   {crop: "", from: "kg/ha", to: "bu/ac", wantSentinel: ErrUnknownCrop},
+		"unknown unit":                 {crop: "corn", from: "kg/ha", to: "bu/xx", wantSentinel: ErrUnknownUnit},
+		"numerator is not a volume":    {crop: "corn", from: "kg/ha", to: "h/ac", wantSentinel: ErrIncompatibleDimensions},
+		"denominator is not an area":   {crop: "corn", from: "kg/ha", to: "bu/h", wantSentinel: ErrIncompatibleDimensions},
+		"malformed from compound":      {crop: "corn", from: "kg", to: "bu/ac", wantSentinel: ErrMalformedCompoundUnit},
+		"numerator is not a mass unit": {crop: "corn", from: "bu/ac", to: "l/ac", wantSentinel: ErrIncompatibleDimensions},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := CropRate(c.crop, 1, c.from, c.to)
+			assert.True(t, errors.Is(err, c.wantSentinel), err)
+		})
+	}
+
+	_, err := CropRate("kale", 1, "kg/ha", "bu/ac")
+	var e *UnknownCropError
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "kale", e.Crop)
+	assert.Contains(t, e.BushelCrops, Corn)
+	assert.Equal(t, []Crop{Cotton}, e.BaleCrops)
+	assert.EqualError(t, err, "unknown crop: kale, must be one of the bushel crops [alfalfa barley corn flax lucerne "+
+		"maize millet oats rye sorghum soybean soybeans spelt wheat], or a bale crop [cotton]")
+}
+
+func TestDilutedProductApplication_Errors(t *testing.T) {
+	t.Parallel()
+
+	valid := DilutedProductApplication{
+		ProductAmount:               10,
+		ProductUnitLabel:            "g",
+		CarrierSolventUnitLabel:     "l",
+		CarrierApplicationAmount:    100,
+		CarrierApplicationUnitLabel: "l",
+		AreaUnitLabel:               "ha",
+	}
+
+	cases := map[string]struct {
+		modify       func(*DilutedProductApplication)
+		wantSentinel error
+	}{
+		"unknown area unit":             {modify: func(d *DilutedProductApplication) { d.AreaUnitLabel = "xx" }, wantSentinel: ErrUnknownUnit},
+		"area unit is not an area":      {modify: func(d *DilutedProductApplication) { d.AreaUnitLabel = "kg" }, wantSentinel: ErrIncompatibleDimensions},
+		"unknown product unit":          {modify: func(d *DilutedProductApplication) { d.ProductUnitLabel = "xx" }, wantSentinel: ErrUnknownUnit},
+		"product is not mass or volume": {modify: func(d *DilutedProductApplication) { d.ProductUnitLabel = "ha" }, wantSentinel: ErrIncompatibleDimensions},
+		"unknown solvent unit":          {modify: func(d *DilutedProductApplication) { d.CarrierSolventUnitLabel = "xx" }, wantSentinel: ErrUnknownUnit},
+		"unknown application unit":      {modify: func(d *DilutedProductApplication) { d.CarrierApplicationUnitLabel = "xx" }, wantSentinel: ErrUnknownUnit},
+		"mixed carrier units":           {modify: func(d *DilutedProductApplication) { d.CarrierApplicationUnitLabel = "kg" }, wantSentinel: ErrIncompatibleDimensions},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := valid
+			c.modify(&d)
+			_, _, err := d.ApplicationRate()
+			assert.True(t, errors.Is(err, c.wantSentinel), err)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// unitDefinitionSource is the source of the factors between units, which are exact by definition, eg 1 ft is
+// 0.3048 m and 1 lb is 453.59237 g.
+const unitDefinitionSource = "unit definitions"
+
+// Explanation is an audit trail of a conversion, with the units that were parsed from the labels and each step that
+// was taken to get from Value to Result, stamped with the version of the factor set that was used.
+type Explanation struct {
+	Value         float64
+	From          Unit
+	To            Unit
+	Result        float64
+	Steps         []ExplanationStep
+	FactorVersion string
+}
+
+// ExplanationStep is one step of a conversion. The value after the step is the value before it multiplied by Factor,
+// plus Offset for a temperature. Value is the value after the step, which for the last step can differ from the
+// Result of the Explanation in the last digits, since the Result is rounded once from the exact conversion. Source
+// summarises where the factor comes from, and Provenance has the provenance of each unit or crop factor it is derived
+// from.
+type ExplanationStep struct {
+	Description string
+	Factor      *big.Rat
+	Offset      *big.Rat
+	Value       float64
+	Source      string
+	Provenance  []Provenance
+}
+
+// String returns the explanation with one line for each step, eg
+//
+//	10 gal1ac-1 = 93.53956228956228 l1ha-1
+//	  gal → l: × 3.785411784 = 37.85411784 (unit definitions)
+//	  per ac → per ha: × 390625000/158080329 = 93.5395622895623 (unit definitions)
+func (e Explanation) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%g %s = %g %s", e.Value, e.From, e.Result, e.To)
+	for _, s := range e.Steps {
+		fmt.Fprintf(&sb, "\n  %s: × %s", s.Description, ratString(s.Factor))
+		if s.Offset != nil && s.Offset.Sign() != 0 {
+			fmt.Fprintf(&sb, " + %s", ratString(s.Offset))
+		}
+		fmt.Fprintf(&sb, " = %g (%s)", s.Value, s.Source)
+	}
+	return sb.String()
+}
+
+// ExplainValueFromTo converts a value from one unit to another as ValueFromTo does, and returns the explanation of
+// the conversion. It returns the same errors as ValueFromTo.
+func ExplainValueFromTo(value float64, fromUnit, toUnit string) (Explanation, error) {
+	result, err := ValueFromTo(value, fromUnit, toUnit)
+	if err != nil {
+		return Explanation{}, err
+	}
+	if fromUnit == toUnit {
+		return unchanged(value, fromUnit), nil
+	}
+	from, to, err := conversionUnits(fromUnit, toUnit)
+	if err != nil {
+		return Explanation{}, err
+	}
+	e := Explanation{Value: value, From: from, To: to, Result: result, FactorVersion: DefaultFactorVersion}
+	if err := e.addUnitSteps(from, to); err != nil {
+		return Explanation{}, err
+	}
+	return e, nil
+}
+
+// ExplainCropRate converts a crop rate as CropRate does, and returns the explanation of the conversion, which includes
+// the bushel or bale weight of the crop. It returns the same errors as CropRate.
+func ExplainCropRate(crop string, value float64, fromCompoundUnit, toCompoundUnit string) (Explanation, error) {
+	return DefaultFactorSet().ExplainCropRate(crop, value, fromCompoundUnit, toCompoundUnit)
+}
+
+// ExplainCropRate explains a crop rate conversion as the ExplainCropRate function does, with the bushel and bale
+// weights of the factor set.
+func (s FactorSet) ExplainCropRate(crop string, value float64, fromUnit, toUnit string) (Explanation, error) {
+	result, err := s.cropRate(crop, value, fromUnit, toUnit)
+	if err != nil {
+		return Explanation{}, err
+	}
+	if fromUnit == toUnit {
+		e := unchanged(value, fromUnit)
+		e.FactorVersion = s.Version
+		return e, nil
+	}
+	from, err := UnitFromLabel(fromUnit)
+	if err != nil {
+		return Explanation{}, err
+	}
+	to, err := UnitFromLabel(toUnit)
+	if err != nil {
+		return Explanation{}, err
+	}
+	from, to = s.pin(from), s.pin(to)
+	e := Explanation{Value: value, From: from, To: to, Result: result, FactorVersion: s.Version}
+
+	c := Crop(strings.ToLower(crop))
+	switch f := from.(type) {
+	case MassAreaRatioUnit:
+		t, ok := to.(VolumeAreaRatioUnit)
+		if !ok {
+			break
+		}
+		container, weight, source := s.cropContainer(c)
+		if err := e.addUnitSteps(f.Numerator, Gram); err != nil {
+			return Explanation{}, err
+		}
+		e.addStep(fmt.Sprintf("g → %s of %s", container, c), new(big.Rat).Inv(weight.exact), source,
+			[]Provenance{weight.source})
+		if err := e.addUnitSteps(container, t.Numerator); err != nil {
+			return Explanation{}, err
+		}
+		if err := e.addAreaStep(f.Denominator, t.Denominator); err != nil {
+			return Explanation{}, err
+		}
+		return e, nil
+	case VolumeAreaRatioUnit:
+		t, ok := to.(MassAreaRatioUnit)
+		if !ok {
+			break
+		}
+		container, weight, source := s.cropContainer(c)
+		if err := e.addUnitSteps(f.Numerator, container); err != nil {
+			return Explanation{}, err
+		}
+		e.addStep(fmt.Sprintf("%s of %s → g", container, c), weight.exact, source, []Provenance{weight.source})
+		if err := e.addUnitSteps(Gram, t.Numerator); err != nil {
+			return Explanation{}, err
+		}
+		if err := e.addAreaStep(f.Denominator, t.Denominator); err != nil {
+			return Explanation{}, err
+		}
+		return e, nil
+	}
+	if err := e.addUnitSteps(from, to); err != nil {
+		return Explanation{}, err
+	}
+	return e, nil
+}
+
+// ExplainApplicationRate returns the application rate of the diluted product, as ApplicationRate does, with the
+// explanation of how it was derived from the carrier application rate and the dilution.
+func (d *DilutedProductApplication) ExplainApplicationRate() (Explanation, error) {
+	rate, _, err := d.ApplicationRate()
+	if err != nil {
+		return Explanation{}, err
+	}
+	e := Explanation{
+		Value:         d.CarrierApplicationAmount,
+		From:          RatioUnit{Numerator: d.carrierApplicationUnit, Denominator: d.areaUnit},
+		To:            RatioUnit{Numerator: d.productUnit, Denominator: d.areaUnit},
+		Result:        rate,
+		FactorVersion: DefaultFactorVersion,
+	}
+	if err := e.addUnitSteps(d.carrierApplicationUnit, d.carrierSolventUnit); err != nil {
+		return Explanation{}, err
+	}
+	amount := new(big.Rat)
+	if amount.SetFloat64(d.ProductAmount) == nil {
+		return Explanation{}, fmt.Errorf("product amount %g is not a finite number", d.ProductAmount)
+	}
+	e.addStep(fmt.Sprintf("%s → %s: %g %s per %s", d.carrierSolventUnit, d.productUnit, d.ProductAmount, d.productUnit,
+		d.carrierSolventUnit), amount, "dilution", nil)
+	return e, nil
+}
+
+// unchanged returns the explanation of a conversion to the same unit label, which has no steps. The units are only
+// set if the label is a known unit, since the value is returned unchanged without reading the label.
+func unchanged(value float64, label string) Explanation {
+	u, _ := UnitFromLabel(label)
+	return Explanation{Value: value, From: u, To: u, Result: value, FactorVersion: DefaultFactorVersion}
+}
+
+// addUnitSteps adds the steps that convert from one unit to another with the same dimension. The units of a ratio or
+// compound unit are converted one at a time when both units have the same shape, eg gal → l then per ac → per ha,
+// otherwise there is one step for the whole unit. Units that do not change have no step. It returns an error if a
+// step has no conversion, so that an explanation never has missing steps.
+func (e *Explanation) addUnitSteps(from, to Unit) error {
+	if f, ok := from.(TemperatureUnit); ok {
+		if t, ok := to.(TemperatureUnit); ok {
+			e.addTemperatureStep(f, t)
+			return nil
+		}
+	}
+	ft, tt := unitTerms(from), unitTerms(to)
+	if !sameShape(ft, tt) {
+		return e.addConversionStep(from, to, 1)
+	}
+	for i := range ft {
+		if err := e.addConversionStep(ft[i].Unit, tt[i].Unit, ft[i].Exponent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAreaStep adds the step that converts the denominator of a per area rate from one area unit to another.
+func (e *Explanation) addAreaStep(from, to AreaUnit) error {
+	return e.addConversionStep(from, to, -1)
+}
+
+// addConversionStep adds the step that converts from one unit to another raised to the power exp, unless they are
+// the same unit. It returns the error from conversionRatio if there is no conversion between the units.
+func (e *Explanation) addConversionStep(from, to Unit, exp int) error {
+	if from.String() == to.String() {
+		return nil
+	}
+	r, err := conversionRatio(from, to)
+	if err != nil {
+		return err
+	}
+	f := new(big.Rat).SetInt64(1)
+	for i := 0; i < abs(exp); i++ {
+		f.Mul(f, r)
+	}
+	if exp < 0 {
+		f.Inv(f)
+	}
+	description := fmt.Sprintf("%s → %s", from, to)
+	switch {
+	case exp < -1:
+		description = fmt.Sprintf("per %s%s → per %s%s", from, superscript(-exp), to, superscript(-exp))
+	case exp == -1:
+		description = fmt.Sprintf("per %s → per %s", from, to)
+	case exp > 1:
+		description = fmt.Sprintf("%s%s → %s%s", from, superscript(exp), to, superscript(exp))
+	}
+	e.addStep(description, f, unitDefinitionSource, append(unitProvenance(from), unitProvenance(to)...))
+	return nil
+}
+
+// addTemperatureStep adds the step that converts a temperature from one scale to another.
+func (e *Explanation) addTemperatureStep(from, to TemperatureUnit) {
+	if from.String() == to.String() {
+		return
+	}
+	f, offset := temperatureScale(from, to)
+	v := e.lastValue()*floatValue(f) + floatValue(offset)
+	e.Steps = append(e.Steps, ExplanationStep{
+		Description: fmt.Sprintf("%s → %s", from, to),
+		Factor:      f,
+		Offset:      offset,
+		Value:       v,
+		Source:      unitDefinitionSource,
+		Provenance:  append(unitProvenance(from), unitProvenance(to)...),
+	})
+}
+
+// addStep adds a step that multiplies the value by the factor.
+func (e *Explanation) addStep(description string, f *big.Rat, source string, provenance []Provenance) {
+	e.Steps = append(e.Steps, ExplanationStep{
+		Description: description,
+		Factor:      f,
+		Value:       e.lastValue() * floatValue(f),
+		Source:      source,
+		Provenance:  provenance,
+	})
+}
+
+// lastValue returns the value after the last step, or the value converted if there are no steps.
+func (e *Explanation) lastValue() float64 {
+	if len(e.Steps) == 0 {
+		return e.Value
+	}
+	return e.Steps[len(e.Steps)-1].Value
+}
+
+// sameShape returns true if the terms of two units can be converted one at a time, ie they have the same number of
+// terms with the same exponents and dimensions.
+func sameShape(from, to []UnitPower) bool {
+	if len(from) != len(to) {
+		return false
+	}
+	for i := range from {
+		if from[i].Exponent != to[i].Exponent {
+			return false
+		}
+		fd, err := DimensionVectorOf(from[i].Unit)
+		if err != nil {
+			return false
+		}
+		td, err := DimensionVectorOf(to[i].Unit)
+		if err != nil || fd != td {
+			return false
+		}
+	}
+	return true
+}
+
+// cropContainer returns the bushel or the bale for the crop, with its weight in grams and the source of the weight.
+func (s FactorSet) cropContainer(c Crop) (VolumeUnit, factor, string) {
+	lb := s.pin(Pound).(MassUnit).conversion
+	if f, ok := s.baleWeights[c]; ok {
+		return s.pin(Bale).(VolumeUnit), f, fmt.Sprintf("%s bale weight, %s lb", c, ratString(ratio(f, lb)))
+	}
+	f := s.bushelWeights[c]
+	return s.pin(Bushel).(VolumeUnit), f, fmt.Sprintf("%s bushel weight, %s lb", c, ratString(ratio(f, lb)))
+}
+
+// floatValue returns the nearest float64 to r.
+func floatValue(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
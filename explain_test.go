@@ -0,0 +1,147 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainValueFromTo(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		value     float64
+		from, to  string
+		wantSteps []string
+		wantFrom  string
+	}{
+		"volume rate":   {value: 10, from: "gal/ac", to: "l/ha", wantSteps: []string{"gal → l", "per ac → per ha"}, wantFrom: "gal1ac-1"},
+		"simple":        {value: 1, from: "ha", to: "ac", wantSteps: []string{"ha → ac"}, wantFrom: "ha"},
+		"compound":      {value: 1, from: "t/ha/yr", to: "lb/ac/mo", wantSteps: []string{"t → lb", "per ha → per ac", "per yr → per mo"}, wantFrom: "t1ha-1yr-1"},
+		"unchanged":     {value: 5, from: "kg/ha", to: "kg/ac", wantSteps: []string{"per ha → per ac"}, wantFrom: "kg1ha-1"},
+		"squared":       {value: 1, from: "m/s2", to: "ft/min2", wantSteps: []string{"m → ft", "per s² → per min²"}, wantFrom: "m1s-2"},
+		"other shape":   {value: 1, from: "l/ha", to: "mm", wantSteps: []string{"l1ha-1 → mm"}, wantFrom: "l1ha-1"},
+		"temperature":   {value: 20, from: "degC", to: "degF", wantSteps: []string{"degC → degF"}, wantFrom: "degC"},
+		"same label":    {value: 3, from: "kg", to: "kg", wantFrom: "kg"},
+		"scaled prefix": {value: 1, from: "kg/ha", to: "lb/1000 ft2", wantSteps: []string{"kg → lb", "per ha → per 1000 ft2"}, wantFrom: "kg1ha-1"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e, err := ExplainValueFromTo(c.value, c.from, c.to)
+			assert.NoError(t, err)
+			want, _ := ValueFromTo(c.value, c.from, c.to)
+			assert.Equal(t, want, e.Result)
+			assert.Equal(t, c.wantFrom, e.From.String())
+			var steps []string
+			for _, s := range e.Steps {
+				steps = append(steps, s.Description)
+				assert.Equal(t, unitDefinitionSource, s.Source)
+			}
+			assert.Equal(t, c.wantSteps, steps)
+			if len(e.Steps) > 0 {
+				assert.InEpsilon(t, e.Result, e.Steps[len(e.Steps)-1].Value, 1e-12)
+			}
+		})
+	}
+}
+
+func TestExplainValueFromTo_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ExplainValueFromTo(1, "kg", "l")
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions), err)
+
+	_, err = ExplainValueFromTo(1, "kg/furlong", "kg/ha")
+	assert.True(t, errors.Is(err, ErrUnknownUnit), err)
+}
+
+func TestExplainCropRate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		crop       string
+		value      float64
+		from, to   string
+		wantSteps  []string
+		wantSource string
+	}{
+		"mass to bushels": {crop: "Corn", value: 10, from: "t/ha", to: "bu/ac", wantSteps: []string{"t → g", "g → bu of corn", "per ha → per ac"}, wantSource: "corn bushel weight, 56 lb"},
+		"bushels to mass": {crop: "wheat", value: 50, from: "bu/ac", to: "kg/ha", wantSteps: []string{"bu of wheat → g", "g → kg", "per ac → per ha"}, wantSource: "wheat bushel weight, 60 lb"},
+		"bales to mass":   {crop: "cotton", value: 2, from: "bale/ac", to: "lb/ac", wantSteps: []string{"bale of cotton → g", "g → lb"}, wantSource: "cotton bale weight, 500 lb"},
+		"oats":            {crop: "oats", value: 100, from: "bu/ac", to: "t/ha", wantSteps: []string{"bu of oats → g", "g → t", "per ac → per ha"}, wantSource: "oats bushel weight, 32 lb"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e, err := ExplainCropRate(c.crop, c.value, c.from, c.to)
+			assert.NoError(t, err)
+			want, _ := CropRate(c.crop, c.value, c.from, c.to)
+			assert.Equal(t, want, e.Result)
+			var steps []string
+			for _, s := range e.Steps {
+				steps = append(steps, s.Description)
+				if s.Source != unitDefinitionSource {
+					assert.Equal(t, c.wantSource, s.Source)
+				}
+			}
+			assert.Equal(t, c.wantSteps, steps)
+			assert.InEpsilon(t, e.Result, e.Steps[len(e.Steps)-1].Value, 1e-12)
+		})
+	}
+
+	_, err := ExplainCropRate("kale", 1, "t/ha", "bu/ac")
+	assert.True(t, errors.Is(err, ErrUnknownCrop), err)
+}
+
+func TestDilutedProductApplication_ExplainApplicationRate(t *testing.T) {
+	t.Parallel()
+
+	d := DilutedProductApplication{
+		ProductAmount:               10,
+		ProductUnitLabel:            "g",
+		CarrierSolventUnitLabel:     "l",
+		CarrierApplicationAmount:    10,
+		CarrierApplicationUnitLabel: "gal",
+		AreaUnitLabel:               "ha",
+	}
+	e, err := d.ExplainApplicationRate()
+	assert.NoError(t, err)
+	assert.Equal(t, "gal1ha-1", e.From.String())
+	assert.Equal(t, "g1ha-1", e.To.String())
+	assert.InDelta(t, 378.5412, e.Result, 0.0001)
+	assert.Equal(t, `10 gal1ha-1 = 378.54117840000004 g1ha-1
+  gal → l: × 3.785411784 = 37.85411784 (unit definitions)
+  l → g: 10 g per l: × 10 = 378.54117840000004 (dilution)`, e.String())
+
+	d.AreaUnitLabel = "kg"
+	_, err = d.ExplainApplicationRate()
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions), err)
+}
+
+func TestExplanation_String(t *testing.T) {
+	t.Parallel()
+
+	e, err := ExplainValueFromTo(20, "degC", "degF")
+	assert.NoError(t, err)
+	assert.Equal(t, "20 degC = 68 degF\n  degC → degF: × 1.8 + 32 = 68 (unit definitions)", e.String())
+}
+
+func TestExplanation_AddUnitSteps_Incompatible(t *testing.T) {
+	t.Parallel()
+
+	var e Explanation
+	err := e.addUnitSteps(Kilogram, Litre)
+	assert.True(t, errors.Is(err, ErrIncompatibleDimensions), err)
+	assert.Empty(t, e.Steps)
+
+	err = e.addUnitSteps(MassAreaRatioUnit{Numerator: Kilogram, Denominator: Hectare},
+		MassAreaRatioUnit{Numerator: Pound, Denominator: Hectare})
+	assert.NoError(t, err)
+	assert.Len(t, e.Steps, 1)
+}
@@ -0,0 +1,90 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// MaxRelativeError is the documented bound on the relative error of the float64 conversion functions, such as
+// ValueFromTo and the measurement To methods, compared with the exact result for the same float64 input.
+// The ratio between the two exact unit factors is computed with big.Rat and rounded to float64 once, then multiplied
+// by the value, so the result carries at most two float64 rounding errors, ie 2 × 2⁻⁵³.
+const MaxRelativeError = 0x1p-52
+
+// factor is a conversion factor to the base unit of a dimension. It holds the exact value, derived from the
+// definition of the unit, and the nearest float64. Crop factors also hold their source.
+type factor struct {
+	exact  *big.Rat
+	value  float64
+	source Provenance
+}
+
+// exactFactor returns the factor for s, which is a decimal string such as "0.3048", or a fraction such as "1/3".
+// It panics if s is not a valid number, so should only be used to initialise the unit tables.
+func exactFactor(s string) factor {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic(fmt.Sprintf("invalid exact factor: %s", s))
+	}
+	f, _ := r.Float64()
+	return factor{
+		exact: r,
+		value: f,
+	}
+}
+
+// withSource returns the factor with the provenance p.
+func (f factor) withSource(p Provenance) factor {
+	f.source = p
+	return f
+}
+
+// String returns the exact factor as a decimal string, or as a fraction if it does not terminate.
+func (f factor) String() string {
+	if f.exact == nil {
+		return fmt.Sprint(f.value)
+	}
+	if n, exact := f.exact.FloatPrec(); exact {
+		return f.exact.FloatString(n)
+	}
+	return f.exact.RatString()
+}
+
+// ratio returns the exact ratio between two factors, eg the number of 'to' units in one 'from' unit.
+func ratio(from, to factor) *big.Rat {
+	return new(big.Rat).Quo(from.exact, to.exact)
+}
+
+// scaleValue converts v using the exact ratio between the from and to factors, rounded once to float64.
+func scaleValue(v float64, from, to factor) float64 {
+	if from.exact == nil || to.exact == nil {
+		return (v * from.value) / to.value
+	}
+	r, _ := ratio(from, to).Float64()
+	return v * r
+}
+
+// scaleRatioValue converts v, a value of a ratio unit, from one numerator / denominator pair to another.
+// The combined ratio is computed exactly so the result carries the same error bound as a simple conversion.
+func scaleRatioValue(v float64, fromNumerator, fromDenominator, toNumerator, toDenominator factor) float64 {
+	if fromNumerator.exact == nil || fromDenominator.exact == nil || toNumerator.exact == nil || toDenominator.exact == nil {
+		return v * (fromNumerator.value / toNumerator.value) / (fromDenominator.value / toDenominator.value)
+	}
+	r := ratio(fromNumerator, toNumerator)
+	r.Quo(r, ratio(fromDenominator, toDenominator))
+	f, _ := r.Float64()
+	return v * f
+}
+
+// ValueFromToExact is the high-precision equivalent of ValueFromTo. The conversion is carried out with exact rational
+// arithmetic using the exact unit definitions, so the result has no rounding error.
+func ValueFromToExact(value *big.Rat, fromUnit string, toUnit string) (*big.Rat, error) {
+	if fromUnit == toUnit {
+		return new(big.Rat).Set(value), nil
+	}
+	from, to, err := conversionUnits(fromUnit, toUnit)
+	if err != nil {
+		return nil, err
+	}
+	return convertUnitsExact(value, from, to)
+}
@@ -0,0 +1,332 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// DefaultFactorVersion is the version of the factor set used by CropRate, ValueFromTo and the other conversion
+// functions that are not pinned to a version.
+const DefaultFactorVersion = "v1"
+
+// FactorSet is a named version of the conversion factors, so that a result can be reproduced with the factors it was
+// first calculated with after a factor is corrected. The versioned factors are the crop bushel and bale weights and the
+// unit factors. A set only holds the unit factors that differ from the unit tables, and temperature scales are not
+// versioned.
+type FactorSet struct {
+	Version string
+
+	bushelWeights map[Crop]factor
+	baleWeights   map[Crop]factor
+	unitFactors   map[string]factor // by standard label, eg gal
+}
+
+// factorSets are the factor sets in version order. A corrected factor is added as a new version, made with
+// withFactors from the latest version, so that the factors of earlier versions never change. v0 has the rounded
+// factors the package used before v1, and v1 has the exact unit definitions.
+var factorSets = []FactorSet{
+	{
+		Version:       "v0",
+		bushelWeights: baselineBushelsToGrams,
+		baleWeights:   baselineBalesToGrams,
+		unitFactors:   baselineUnitFactors,
+	},
+	{Version: "v1", bushelWeights: cropBushelsToGrams, baleWeights: cropBalesToGrams},
+}
+
+// baselineUnitFactors are the unit factors of factor set v0 that differ from the unit tables, which were rounded to
+// about six significant figures.
+var baselineUnitFactors = map[string]factor{
+	SquareFootStandard.String(): exactFactor("0.092903").withSource(packageBaseline),
+	SquareYardStandard.String(): exactFactor("0.836127").withSource(packageBaseline),
+	SquareMileStandard.String(): exactFactor("2589988.11").withSource(packageBaseline),
+	AcreStandard.String():       exactFactor("4046.86").withSource(packageBaseline),
+	MileStandard.String():       exactFactor("1609.34").withSource(packageBaseline),
+	OunceMassStandard.String():  exactFactor("28.3495").withSource(packageBaseline),
+	PoundStandard.String():      exactFactor("453.592").withSource(packageBaseline),
+	StoneStandard.String():      exactFactor("6350.29").withSource(packageBaseline),
+	TonStandard.String():        exactFactor("907185").withSource(packageBaseline),
+	GallonStandard.String():     exactFactor("3.78541").withSource(packageBaseline),
+	FluidOunceStandard.String(): exactFactor("0.0295735").withSource(packageBaseline),
+	QuartStandard.String():      exactFactor("0.946353").withSource(packageBaseline),
+	PintStandard.String():       exactFactor("0.473176").withSource(packageBaseline),
+	CubicInchStandard.String():  exactFactor("0.0163871").withSource(packageBaseline),
+	CubicFootStandard.String():  exactFactor("28.3168").withSource(packageBaseline),
+	CubicYardStandard.String():  exactFactor("764.555").withSource(packageBaseline),
+	AcreFootStandard.String():   exactFactor("1233480").withSource(packageBaseline),
+	BushelStandard.String():     exactFactor("35.2391").withSource(packageBaseline),
+}
+
+// FactorChange is a factor that differs between two factor sets, with its exact value in each. Old is empty if the
+// factor was added, and New is empty if it was removed.
+type FactorChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// String returns the change, eg "bushel/oats: 14514.95584 → 15422.14058".
+func (c FactorChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.Name, valueOrNone(c.Old), valueOrNone(c.New))
+}
+
+// ConversionResult is a converted value stamped with the version of the factor set it was converted with.
+type ConversionResult struct {
+	Value         float64
+	FactorVersion string
+}
+
+// FactorSets returns the factor sets in version order.
+func FactorSets() []FactorSet {
+	return append([]FactorSet(nil), factorSets...)
+}
+
+// DefaultFactorSet returns the factor set with version DefaultFactorVersion.
+func DefaultFactorSet() FactorSet {
+	s, err := LookupFactorSet(DefaultFactorVersion)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// LookupFactorSet returns the factor set with the version, or an error if there is no such version.
+func LookupFactorSet(version string) (FactorSet, error) {
+	for _, s := range factorSets {
+		if s.Version == version {
+			return s, nil
+		}
+	}
+	return FactorSet{}, fmt.Errorf("unknown factor set version %s", version)
+}
+
+// DiffFactorSets returns the factors that changed between two versions of the factor set, sorted by name. It returns
+// an error if either version is unknown.
+func DiffFactorSets(oldVersion, newVersion string) ([]FactorChange, error) {
+	o, err := LookupFactorSet(oldVersion)
+	if err != nil {
+		return nil, err
+	}
+	n, err := LookupFactorSet(newVersion)
+	if err != nil {
+		return nil, err
+	}
+	return o.Diff(n), nil
+}
+
+// Diff returns the factors that differ between s and n, sorted by name.
+func (s FactorSet) Diff(n FactorSet) []FactorChange {
+	old, next := s.Factors(), n.Factors()
+	var changes []FactorChange
+	for name, v := range old {
+		if next[name] != v {
+			changes = append(changes, FactorChange{Name: name, Old: v, New: next[name]})
+		}
+	}
+	for name, v := range next {
+		if _, ok := old[name]; !ok {
+			changes = append(changes, FactorChange{Name: name, New: v})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// Factors returns the exact value of each factor in the set, by name. Bushel weights are named bushel/crop and bale
+// weights bale/crop, eg bushel/corn, and are in grams. Unit factors are named unit/label, eg unit/gal, and are in the
+// base unit of the dimension, as in UnitFactorMetadata.
+func (s FactorSet) Factors() map[string]string {
+	units := simpleUnits()
+	m := make(map[string]string, len(s.bushelWeights)+len(s.baleWeights)+len(units))
+	for c, f := range s.bushelWeights {
+		m["bushel/"+string(c)] = f.String()
+	}
+	for c, f := range s.baleWeights {
+		m["bale/"+string(c)] = f.String()
+	}
+	for _, u := range units {
+		f, _ := s.unitFactor(u)
+		m["unit/"+u.String()] = f.String()
+	}
+	return m
+}
+
+// ValueFromTo converts a value from one unit to another as ValueFromTo does, with the unit factors of the factor set,
+// and stamps the result with the version of the factor set.
+func (s FactorSet) ValueFromTo(value float64, fromUnit, toUnit string) (ConversionResult, error) {
+	v, err := s.valueFromTo(value, fromUnit, toUnit)
+	if err != nil {
+		return ConversionResult{}, err
+	}
+	return ConversionResult{Value: v, FactorVersion: s.Version}, nil
+}
+
+// valueFromTo is ValueFromTo with the unit factors of the factor set. A set with the unit factors of the unit tables
+// takes the same conversion path as ValueFromTo.
+func (s FactorSet) valueFromTo(value float64, fromUnit, toUnit string) (float64, error) {
+	if len(s.unitFactors) == 0 {
+		return ValueFromTo(value, fromUnit, toUnit)
+	}
+	if fromUnit == toUnit {
+		return value, nil
+	}
+	from, to, err := conversionUnits(fromUnit, toUnit)
+	if err != nil {
+		return 0, err
+	}
+	return convertUnits(value, s.pin(from), s.pin(to))
+}
+
+// CropRate converts a crop rate as CropRate does, with the bushel and bale weights and unit factors of the factor set,
+// and stamps the result with the version of the factor set.
+func (s FactorSet) CropRate(crop string, value float64, fromUnit, toUnit string) (ConversionResult, error) {
+	v, err := s.cropRate(crop, value, fromUnit, toUnit)
+	if err != nil {
+		return ConversionResult{}, err
+	}
+	return ConversionResult{Value: v, FactorVersion: s.Version}, nil
+}
+
+// WithFactors returns a copy of the factor set with a new version and the factors replaced, so that corrected factors
+// can be used before they are added to the package as a version. The factors are named as in Factors, eg
+// bushel/canola, bale/cotton or unit/gal, and their values are exact decimals or fractions, in grams for a crop
+// factor and in the base unit of the dimension for a unit factor. Each factor has the provenance source. It returns an
+// error if a name or value is not valid, or the unit of a unit factor is not a simple unit or is a temperature.
+func (s FactorSet) WithFactors(version string, factors map[string]string, source Provenance) (FactorSet, error) {
+	bushels := make(map[Crop]factor)
+	bales := make(map[Crop]factor)
+	units := make(map[string]factor)
+	for name, v := range factors {
+		r, ok := new(big.Rat).SetString(v)
+		if !ok || r.Sign() <= 0 {
+			return FactorSet{}, fmt.Errorf("factor %s has value %s, which is not a positive number", name, v)
+		}
+		f, _ := r.Float64()
+		x := factor{exact: r, value: f, source: source}
+		kind, key, _ := strings.Cut(name, "/")
+		switch {
+		case key == "":
+			return FactorSet{}, fmt.Errorf("factor %s is not named bushel/crop, bale/crop or unit/label", name)
+		case kind == "bushel":
+			bushels[Crop(strings.ToLower(key))] = x
+		case kind == "bale":
+			bales[Crop(strings.ToLower(key))] = x
+		case kind == "unit":
+			u, ok := lookupSimpleUnit(key)
+			if !ok {
+				return FactorSet{}, fmt.Errorf("factor %s: %w", name, &UnknownUnitError{Label: key})
+			}
+			if _, ok := u.(TemperatureUnit); ok {
+				return FactorSet{}, fmt.Errorf("factor %s: temperature scales are not versioned", name)
+			}
+			units[u.String()] = x
+		default:
+			return FactorSet{}, fmt.Errorf("factor %s is not named bushel/crop, bale/crop or unit/label", name)
+		}
+	}
+	n := s.withFactors(version, bushels, bales)
+	for label, f := range units {
+		n.unitFactors[label] = f
+	}
+	return n, nil
+}
+
+// withFactors returns a copy of the factor set with a new version, and the bushel and bale weights replaced by the
+// weights in bushels and bales, which are in grams and should have their source.
+func (s FactorSet) withFactors(version string, bushels, bales map[Crop]factor) FactorSet {
+	n := FactorSet{
+		Version:       version,
+		bushelWeights: make(map[Crop]factor, len(s.bushelWeights)),
+		baleWeights:   make(map[Crop]factor, len(s.baleWeights)),
+		unitFactors:   make(map[string]factor, len(s.unitFactors)),
+	}
+	for c, f := range s.bushelWeights {
+		n.bushelWeights[c] = f
+	}
+	for c, f := range s.baleWeights {
+		n.baleWeights[c] = f
+	}
+	for label, f := range s.unitFactors {
+		n.unitFactors[label] = f
+	}
+	for c, f := range bushels {
+		n.bushelWeights[c] = f
+	}
+	for c, f := range bales {
+		n.baleWeights[c] = f
+	}
+	return n
+}
+
+// unitFactor returns the factor of a simple unit in the factor set, and the label of the base unit of its table.
+func (s FactorSet) unitFactor(u Unit) (factor, string) {
+	f, base := unitFactor(u)
+	if v, ok := s.unitFactors[u.String()]; ok {
+		return v, base
+	}
+	return f, base
+}
+
+// pin returns u with the unit factors of the factor set, so that it converts as it did in that version. Ratio, scaled
+// and compound units have their simple units pinned. Temperature scales are not versioned, so are returned unchanged.
+func (s FactorSet) pin(u Unit) Unit {
+	if len(s.unitFactors) == 0 {
+		return u
+	}
+	switch v := u.(type) {
+	case AreaUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case LineUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case MassUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case TimeUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case VolumeUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case AmountUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case CountUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case FractionUnit:
+		v.conversion, _ = s.unitFactor(v)
+		return v
+	case MassAreaRatioUnit:
+		return MassAreaRatioUnit{Numerator: s.pin(v.Numerator).(MassUnit), Denominator: s.pin(v.Denominator).(AreaUnit)}
+	case VolumeAreaRatioUnit:
+		return VolumeAreaRatioUnit{
+			Numerator:   s.pin(v.Numerator).(VolumeUnit),
+			Denominator: s.pin(v.Denominator).(AreaUnit),
+		}
+	case RatioUnit:
+		return RatioUnit{Numerator: s.pin(v.Numerator), Denominator: s.pin(v.Denominator)}
+	case ScaledUnit:
+		return ScaledUnit{Scale: v.Scale, Unit: s.pin(v.Unit)}
+	case CompoundUnit:
+		terms := make([]UnitPower, len(v.Terms))
+		for i, t := range v.Terms {
+			terms[i] = UnitPower{Unit: s.pin(t.Unit), Exponent: t.Exponent}
+		}
+		return CompoundUnit{Terms: terms}
+	}
+	return u
+}
+
+// valueOrNone returns v, or "none" if it is empty.
+func valueOrNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
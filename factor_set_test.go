@@ -0,0 +1,247 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupFactorSet(t *testing.T) {
+	t.Parallel()
+
+	s, err := LookupFactorSet(DefaultFactorVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultFactorVersion, s.Version)
+	assert.Equal(t, "25401.17272", s.Factors()["bushel/corn"])
+	assert.Equal(t, "226796.185", s.Factors()["bale/cotton"])
+	assert.Equal(t, "3.785411784", s.Factors()["unit/gal"])
+	assert.Equal(t, []string{"v0", "v1"}, []string{FactorSets()[0].Version, FactorSets()[1].Version})
+
+	_, err = LookupFactorSet("v9")
+	assert.EqualError(t, err, "unknown factor set version v9")
+}
+
+func TestFactorSet_Baseline(t *testing.T) {
+	t.Parallel()
+
+	v0, err := LookupFactorSet("v0")
+	assert.NoError(t, err)
+	assert.Equal(t, "25400", v0.Factors()["bushel/corn"])
+	assert.Equal(t, "226800", v0.Factors()["bale/cotton"])
+	assert.Equal(t, "1", v0.Factors()["unit/l"])
+
+	cases := map[string]struct {
+		from string
+		to   string
+		want float64
+	}{
+		"gallon":      {from: "gal", to: "l", want: 3.78541},
+		"ton":         {from: "ton", to: "kg", want: 907.185},
+		"acre-inch":   {from: "ac-in", to: "gal", want: 102790.15312896 / 3.78541},
+		"rate":        {from: "gal/ac", to: "l/ha", want: 3.78541 / 0.404686},
+		"compound":    {from: "lb/ft3", to: "kg/m3", want: 0.453592 / 0.0283168},
+		"scaled":      {from: "lb/1000 ft2", to: "g/m2", want: 453.592 / 92.903},
+		"temperature": {from: "degC", to: "degF", want: 33.8},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := v0.ValueFromTo(1, c.from, c.to)
+			assert.NoError(t, err)
+			assert.Equal(t, "v0", got.FactorVersion)
+			assert.InDelta(t, c.want, got.Value, c.want*1e-12)
+		})
+	}
+
+	got, err := v0.ValueFromTo(1, "ac-in", "gal")
+	assert.NoError(t, err)
+	assert.InDelta(t, 27154.3, got.Value, 0.05)
+
+	got, err = v0.CropRate("corn", 1, "bu/ac", "kg/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 25.4, got.Value, 1e-9)
+
+	got, err = v0.CropRate("cotton", 1, "bale/ac", "lb/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 226800/453.592, got.Value, 1e-9)
+
+	got, err = v0.CropRate("corn", 1, "t/ha", "bu/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1e6/25400*0.404686, got.Value, 1e-9)
+
+	e, err := v0.ExplainCropRate("corn", 1, "t/ha", "bu/ac")
+	assert.NoError(t, err)
+	assert.Equal(t, got.Value, e.Result)
+	assert.InDelta(t, e.Result, e.Steps[len(e.Steps)-1].Value, 1e-9)
+	assert.Equal(t, "corn bushel weight, 3175000/56699 lb", e.Steps[1].Source)
+	assert.Equal(t, []Provenance{packageBaseline}, e.Steps[1].Provenance)
+	assert.Contains(t, e.Steps[2].Provenance, packageBaseline)
+
+	c, err := v0.NewCropConverter("corn", "bu/ac", "kg/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 25.4, c.Convert(1), 1e-9)
+}
+
+func TestDiffFactorSets_Baseline(t *testing.T) {
+	t.Parallel()
+
+	changes, err := DiffFactorSets("v0", DefaultFactorVersion)
+	assert.NoError(t, err)
+	assert.Contains(t, changes, FactorChange{Name: "bushel/corn", Old: "25400", New: "25401.17272"})
+	assert.Contains(t, changes, FactorChange{Name: "bale/cotton", Old: "226800", New: "226796.185"})
+	assert.Contains(t, changes, FactorChange{Name: "unit/gal", Old: "3.78541", New: "3.785411784"})
+	assert.Contains(t, changes, FactorChange{Name: "unit/ton", Old: "907185", New: "907184.74"})
+	for _, c := range changes {
+		assert.NotEqual(t, "unit/ac-in", c.Name, "the acre-inch is the same in both versions")
+		assert.NotEqual(t, "unit/l", c.Name)
+	}
+}
+
+func TestFactorSet_Diff(t *testing.T) {
+	t.Parallel()
+
+	v1 := DefaultFactorSet()
+	v2 := v1.withFactors("v2", map[Crop]factor{Oats: exactFactor("15422.14058"), "canola": exactFactor("22679.6185")}, nil)
+
+	changes := v1.Diff(v2)
+	assert.Equal(t, []FactorChange{
+		{Name: "bushel/canola", New: "22679.6185"},
+		{Name: "bushel/oats", Old: "14514.95584", New: "15422.14058"},
+	}, changes)
+	assert.Equal(t, "bushel/canola: none → 22679.6185", changes[0].String())
+	assert.Equal(t, "bushel/oats: 14514.95584 → 15422.14058", changes[1].String())
+
+	assert.Empty(t, v1.Diff(v1))
+	assert.Equal(t, "14514.95584", v1.Factors()["bushel/oats"], "earlier versions are unchanged")
+}
+
+func TestDiffFactorSets(t *testing.T) {
+	t.Parallel()
+
+	changes, err := DiffFactorSets(DefaultFactorVersion, DefaultFactorVersion)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = DiffFactorSets(DefaultFactorVersion, "v9")
+	assert.EqualError(t, err, "unknown factor set version v9")
+}
+
+func TestFactorSet_CropRate(t *testing.T) {
+	t.Parallel()
+
+	v1 := DefaultFactorSet()
+	v2 := v1.withFactors("v2", map[Crop]factor{Oats: exactFactor("15422.14058")}, nil)
+
+	want, err := CropRate("oats", 100, "bu/ac", "kg/ha")
+	assert.NoError(t, err)
+
+	got, err := v1.CropRate("oats", 100, "bu/ac", "kg/ha")
+	assert.NoError(t, err)
+	assert.Equal(t, ConversionResult{Value: want, FactorVersion: "v1"}, got)
+
+	got, err = v2.CropRate("oats", 100, "bu/ac", "kg/ha")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", got.FactorVersion)
+	assert.InDelta(t, want*34/32, got.Value, 0.0001)
+
+	e, err := v2.ExplainCropRate("oats", 100, "bu/ac", "kg/ha")
+	assert.NoError(t, err)
+	assert.Equal(t, got.Value, e.Result)
+	assert.Equal(t, "v2", e.FactorVersion)
+
+	_, err = v1.CropRate("kale", 1, "t/ha", "bu/ac")
+	assert.Error(t, err)
+}
+
+func TestFactorSet_CropRate_AddedCrop(t *testing.T) {
+	t.Parallel()
+
+	v1 := DefaultFactorSet()
+	v2 := v1.withFactors("v2", map[Crop]factor{"canola": exactFactor("22679.6185")}, nil) // 50 lb
+
+	got, err := v2.CropRate("Canola", 1, "bu/ac", "kg/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 22.6796185, got.Value, 1e-9)
+
+	got, err = v2.CropRate("canola", 22.6796185, "kg/ac", "bu/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, got.Value, 1e-9)
+
+	c, err := v2.NewCropConverter("canola", "bu/ac", "kg/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 22.6796185, c.Convert(1), 1e-9)
+
+	_, err = v1.CropRate("canola", 1, "bu/ac", "kg/ac")
+	assert.True(t, errors.Is(err, ErrUnknownCrop), err)
+
+	_, err = v2.CropRate("kale", 1, "bu/ac", "kg/ac")
+	var e *UnknownCropError
+	if assert.True(t, errors.As(err, &e), err) {
+		assert.Equal(t, []Crop{Alfalfa, Barley, "canola", Corn}, e.BushelCrops[:4])
+	}
+}
+
+func TestFactorSet_ValueFromTo(t *testing.T) {
+	t.Parallel()
+
+	got, err := DefaultFactorSet().ValueFromTo(1, "ha", "ac")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultFactorVersion, got.FactorVersion)
+	assert.InDelta(t, 2.4711, got.Value, 0.0001)
+
+	_, err = DefaultFactorSet().ValueFromTo(1, "kg", "l")
+	assert.Error(t, err)
+}
+
+func TestFactorSet_WithFactors(t *testing.T) {
+	t.Parallel()
+
+	p := Provenance{Source: "Canola Council of Canada"}
+	v2, err := DefaultFactorSet().WithFactors("v2", map[string]string{
+		"bushel/Canola": "22679.6185",
+		"unit/gallon":   "4.54609",
+	}, p)
+	assert.NoError(t, err)
+	assert.Equal(t, []FactorChange{
+		{Name: "bushel/canola", New: "22679.6185"},
+		{Name: "unit/gal", Old: "3.785411784", New: "4.54609"},
+	}, DefaultFactorSet().Diff(v2))
+
+	got, err := v2.CropRate("canola", 1, "bu/ac", "kg/ac")
+	assert.NoError(t, err)
+	assert.Equal(t, ConversionResult{Value: 22.6796185, FactorVersion: "v2"}, got)
+
+	got, err = v2.ValueFromTo(1, "gal", "l")
+	assert.NoError(t, err)
+	assert.Equal(t, ConversionResult{Value: 4.54609, FactorVersion: "v2"}, got)
+
+	for _, m := range v2.AllFactorMetadata() {
+		if m.Name == "unit/gal" || m.Name == "bushel/canola" {
+			assert.Equal(t, p, m.Provenance, m.Name)
+		}
+	}
+
+	cases := map[string]struct {
+		factors map[string]string
+		wantErr string
+	}{
+		"no kind":     {factors: map[string]string{"corn": "1"}, wantErr: "factor corn is not named bushel/crop, bale/crop or unit/label"},
+		"wrong kind":  {factors: map[string]string{"sack/corn": "1"}, wantErr: "factor sack/corn is not named bushel/crop, bale/crop or unit/label"},
+		"not number":  {factors: map[string]string{"bushel/corn": "56 lb"}, wantErr: "factor bushel/corn has value 56 lb, which is not a positive number"},
+		"zero":        {factors: map[string]string{"unit/gal": "0"}, wantErr: "factor unit/gal has value 0, which is not a positive number"},
+		"unknown":     {factors: map[string]string{"unit/furlong": "201"}, wantErr: "factor unit/furlong: unhandled unit label: furlong"},
+		"temperature": {factors: map[string]string{"unit/degF": "0.5"}, wantErr: "factor unit/degF: temperature scales are not versioned"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := DefaultFactorSet().WithFactors("v2", c.factors, p)
+			assert.EqualError(t, err, c.wantErr)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package convert
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueFromToExact(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		value   string
+		from    string
+		to      string
+		want    string
+		wantErr bool
+	}{
+		"acre to square metres":        {value: "1", from: "ac", to: "m2", want: "4046.8564224"},
+		"mile to metres":               {value: "1", from: "mi", to: "m", want: "1609.344"},
+		"square mile to square metres": {value: "1", from: "mi2", to: "m2", want: "2589988.110336"},
+		"pound to kilograms":           {value: "1", from: "lb", to: "kg", want: "0.45359237"},
+		"gallon to litres":             {value: "1", from: "gal", to: "l", want: "3.785411784"},
+		"acre to hectares":             {value: "1", from: "ac", to: "ha", want: "0.40468564224"},
+		"acre inch to gallons":         {value: "7", from: "ac-in", to: "gal", want: "190080"},
+		"lb/ac to kg/ha":               {value: "4046.8564224", from: "lb1ac-1", to: "kg1ha-1", want: "4535.9237"},
+		"gal/ac to l/ha":               {value: "40468564224", from: "gal/ac", to: "l/ha", want: "378541178400"},
+		"same unit":                    {value: "1.5", from: "kg", to: "kg", want: "1.5"},
+		"mass to volume":               {value: "1", from: "kg", to: "l", wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			v, _ := new(big.Rat).SetString(c.value)
+			got, err := ValueFromToExact(v, c.from, c.to)
+			assert.Equal(t, c.wantErr, err != nil)
+			if c.wantErr {
+				return
+			}
+			want, _ := new(big.Rat).SetString(c.want)
+			assert.Equal(t, 0, want.Cmp(got), "got %s, want %s", got.FloatString(15), c.want)
+		})
+	}
+}
+
+// Checks that the float64 conversions are within MaxRelativeError of the exact result.
+func TestMaxRelativeError(t *testing.T) {
+	t.Parallel()
+
+	labels := []string{
+		"ac", "ha", "m2", "mi2", "ft2", "in",
+		"ft", "mi", "km", "lb", "ozm", "ton",
+		"st", "gal", "floz", "bu", "ac-ft", "kg/ha",
+		"lb/ac", "gal/ac", "l/ha", "floz/ft2",
+	}
+	values := []float64{1, 0.1, 93.5396, 1e-9, 123456.789}
+	for _, from := range labels {
+		for _, to := range labels {
+			if conversionFunc(from, to) == nil {
+				continue
+			}
+			for _, v := range values {
+				got, err := ValueFromTo(v, from, to)
+				assert.NoError(t, err)
+				exact, err := ValueFromToExact(new(big.Rat).SetFloat64(v), from, to)
+				assert.NoError(t, err)
+				want, _ := exact.Float64()
+				assert.LessOrEqual(t, math.Abs(got-want)/math.Abs(want), MaxRelativeError, "%v %s to %s", v, from, to)
+			}
+		}
+	}
+}
+
+func Test_factorString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "4046.8564224", Acre.conversion.String())
+	assert.Equal(t, "1", Litre.conversion.String())
+	assert.Equal(t, "1/3", exactFactor("1/3").String())
+}
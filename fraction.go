@@ -0,0 +1,108 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Fraction string
+
+const (
+	PercentStandard         Fraction = "%"
+	PerMilleStandard        Fraction = "permille"
+	PartsPerMillionStandard Fraction = "ppm"
+	PartsPerBillionStandard Fraction = "ppb"
+)
+
+// String returns the string representation of the fraction unit.
+func (f Fraction) String() string {
+	return string(f)
+}
+
+// FractionUnit represents a dimensionless unit for a proportion of a whole, such as a percentage.
+type FractionUnit struct {
+	unit       Fraction
+	full       string
+	fancy      string
+	aliases    []string
+	conversion factor
+}
+
+// String returns the string representation of the base fraction unit.
+func (u FractionUnit) String() string {
+	return u.unit.String()
+}
+
+// Matches returns true if s matches the fraction unit.
+func (u FractionUnit) Matches(s string) bool {
+	if strings.EqualFold(u.String(), s) ||
+		strings.EqualFold(u.fancy, s) ||
+		strings.EqualFold(u.full, s) {
+		return true
+	}
+	for _, alias := range u.aliases {
+		if strings.EqualFold(alias, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// fractionUnits is a list of all supported fraction units.
+var fractionUnits = []FractionUnit{
+	Percent,
+	PerMille,
+	PartsPerMillion,
+	PartsPerBillion,
+}
+
+var Percent = FractionUnit{
+	unit:  PercentStandard,
+	full:  "percent",
+	fancy: string(PercentStandard),
+	aliases: []string{
+		"per cent",
+		"pct",
+	},
+	conversion: exactFactor("0.01"),
+}
+
+var PerMille = FractionUnit{
+	unit:  PerMilleStandard,
+	full:  "per mille",
+	fancy: "‰",
+	aliases: []string{
+		"per mil",
+	},
+	conversion: exactFactor("0.001"),
+}
+
+var PartsPerMillion = FractionUnit{
+	unit:  PartsPerMillionStandard,
+	full:  "part per million",
+	fancy: string(PartsPerMillionStandard),
+	aliases: []string{
+		"parts per million",
+	},
+	conversion: exactFactor("0.000001"),
+}
+
+var PartsPerBillion = FractionUnit{
+	unit:  PartsPerBillionStandard,
+	full:  "part per billion",
+	fancy: string(PartsPerBillionStandard),
+	aliases: []string{
+		"parts per billion",
+	},
+	conversion: exactFactor("0.000000001"),
+}
+
+// fractionUnitFromString returns the first fraction unit that matches s.
+func fractionUnitFromString(s string) (FractionUnit, error) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(FractionUnit); ok {
+			return u, nil
+		}
+	}
+	return FractionUnit{}, fmt.Errorf("no fraction unit found for %s", s)
+}
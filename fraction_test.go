@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fractionUnitFromString(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		argList  []string
+		wantUnit FractionUnit
+		wantErr  bool
+	}{
+		"percent": {
+			argList:  []string{"%", "percent", "per cent", "pct"},
+			wantUnit: Percent,
+			wantErr:  false,
+		},
+		"per mille": {
+			argList:  []string{"permille", "‰", "per mille"},
+			wantUnit: PerMille,
+			wantErr:  false,
+		},
+		"parts per million": {
+			argList:  []string{"ppm", "PPM", "parts per million"},
+			wantUnit: PartsPerMillion,
+			wantErr:  false,
+		},
+		"parts per billion": {
+			argList:  []string{"ppb", "part per billion"},
+			wantUnit: PartsPerBillion,
+			wantErr:  false,
+		},
+		"no match": {
+			argList:  []string{"ppt", "percentage", "pc"},
+			wantUnit: FractionUnit{},
+			wantErr:  true,
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, arg := range c.argList {
+				gotUnit, err := fractionUnitFromString(arg)
+				assert.Equal(t, c.wantErr, err != nil)
+				assert.Equal(t, c.wantUnit, gotUnit)
+			}
+		})
+	}
+}
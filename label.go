@@ -1,127 +1,269 @@
 package convert
 
-//
-// import "fmt"
-//
-// var labelsWithSuperscript = map[any]string{
-// 	SquareCentimetre: "cm²",
-// 	CubicCentimetre:  "cm³",
-// 	SquareMetre:      "m²",
-// 	CubicMetre:       "m³",
-// 	SquareKilometre:  "km²",
-// 	SquareInch:       "in²",
-// 	CubicInch:        "in³",
-// 	SquareFoot:       "ft²",
-// 	CubicFoot:        "ft³",
-// 	CubicYard:        "yd³",
-// 	SquareMile:       "mi²",
-// }
-//
-// var labelsFullWord = map[string]string{
-// 	"mm":       "millimetre",
-// 	"cm":       "centimetre",
-// 	"m2":       "square metre",
-// 	"km":       "kilometre",
-// 	"in":       "inch",
-// 	"ft":       "foot",
-// 	"yd":       "yard",
-// 	"mi":       "mile",
-// 	"ac":       "acre",
-// 	"ha":       "hectare",
-// 	"mg":       "milligram",
-// 	"g":        "gram",
-// 	"kg":       "kilogram",
-// 	"t":        "tonne",
-// 	"lb":       "pound",
-// 	"oz":       "ounce",
-// 	"st":       "stone",
-// 	"s":        "second",
-// 	"min":      "minute",
-// 	"h":        "hour",
-// 	"d":        "day",
-// 	"wk":       "week",
-// 	"mo":       "month",
-// 	"yr":       "year",
-// 	"ml":       "millilitre",
-// 	"l":        "litre",
-// 	"m3":       "cubic metre",
-// 	"km3":      "cubic kilometre",
-// 	"ft3":      "cubic foot",
-// 	"yd3":      "cubic yard",
-// 	"ac ft":    "acre foot",
-// 	"ac in":    "acre inch",
-// 	"US gal":   "US gallon",
-// 	"US qt":    "US quart",
-// 	"US pt":    "US pint",
-// 	"US fl oz": "US fluid ounce",
-// 	"mm3":      "cubic millimetre",
-// 	"cm3":      "cubic centimetre",
-// 	"dm3":      "cubic decimetre",
-// 	"Ml":       "megalitre",
-// 	"hl":       "hectolitre",
-// 	"dal":      "decalitre",
-// 	"dl":       "decilitre",
-// 	"cl":       "centilitre",
-// 	"mm2":      "square millimetre",
-// 	"cm2":      "square centimetre",
-// 	"km2":      "square kilometre",
-// 	"in2":      "square inch",
-// 	"ft2":      "square foot",
-// 	"yd2":      "square yard",
-// 	"mi2":      "square mile",
-// 	"in3":      "cubic inch",
-// }
-//
-// type UnitLabel string
-//
-// // NewUnitLabel returns a UnitLabel for a unit enum
-// // The arg could be an actual unit enum, a string representing a unit enum, or a straight-up string label
-// func NewUnitLabel(enum any) (UnitLabel, error) {
-// 	switch enum.(type) {
-// 	case AreaUnit:
-// 		return UnitLabel(areaLabels[enum]), nil
-// 	case LineUnit:
-// 		return UnitLabel(linearLabels[enum]), nil
-// 	case MassUnit:
-// 		return UnitLabel(massLabels[enum]), nil
-// 	case TimeUnit:
-// 		return UnitLabel(timeLabels[enum]), nil
-// 	case VolumeUnit:
-// 		return UnitLabel(volumeLabels[enum]), nil
-// 	case string:
-// 		return newUnitLabelFromString(enum.(string))
-// 	default:
-// 		return "??", fmt.Errorf("unknown unit enum %v", enum)
-// 	}
-// }
-//
-// // newUnitLabelFromString returns a UnitLabel for a string representing a unit enum
-// func newUnitLabelFromString(s string) (UnitLabel, error) {
-// 	if s == "" {
-// 		return "", fmt.Errorf("empty string")
-// 	}
-// 	if enum := standardLabelFromMaps(s); enum != nil {
-// 		return UnitLabel(s), nil
-// 	}
-// 	return "", fmt.Errorf("unknown unit label %s", s)
-// }
-//
-// // String returns the standard string label for a unit enum
-// func (u UnitLabel) String() string {
-// 	return string(u)
-// }
-//
-// func (u UnitLabel) WithSuperscript() string {
-// 	if s, ok := labelsWithSuperscript[u.String()]; ok {
-// 		return s
-// 	}
-// 	return u.String()
-// }
-//
-// func (u UnitLabel) FullWord() string {
-// 	if s, ok := labelsFullWord[u.String()]; ok {
-// 		return s
-// 	}
-// 	return u.String()
-// }
-//
+import (
+	"fmt"
+	"strings"
+)
+
+// superscripts are the superscript forms of the characters used in unit exponents.
+var superscripts = map[rune]rune{
+	'0': '⁰',
+	'1': '¹',
+	'2': '²',
+	'3': '³',
+	'4': '⁴',
+	'5': '⁵',
+	'6': '⁶',
+	'7': '⁷',
+	'8': '⁸',
+	'9': '⁹',
+	'-': '⁻',
+}
+
+// irregularPlurals are the plural forms of unit names that do not simply take an 's'.
+var irregularPlurals = map[string]string{
+	"foot":              "feet",
+	"ounce mass":        "ounces mass",
+	"degree Celsius":    "degrees Celsius",
+	"degree Fahrenheit": "degrees Fahrenheit",
+	"dozen":             "dozen",
+	"thousand":          "thousand",
+	"percent":           "percent",
+	"per mille":         "per mille",
+	"part per million":  "parts per million",
+	"part per billion":  "parts per billion",
+}
+
+// UnitLabel renders a unit for display as a symbol or in words.
+type UnitLabel struct {
+	unit Unit
+}
+
+// NewUnitLabel returns a UnitLabel for a unit. The arg can be a Unit, such as Kilogram or
+// MassAreaRatioUnit{Kilogram, Hectare}, or a string label that is resolved with UnitFromLabel.
+func NewUnitLabel(unit any) (UnitLabel, error) {
+	switch u := unit.(type) {
+	case string:
+		if u == "" {
+			return UnitLabel{}, fmt.Errorf("empty unit label")
+		}
+		v, err := UnitFromLabel(u)
+		if err != nil {
+			return UnitLabel{}, err
+		}
+		return UnitLabel{unit: v}, nil
+	case Unit:
+		if u == nil {
+			return UnitLabel{}, fmt.Errorf("nil unit")
+		}
+		return UnitLabel{unit: u}, nil
+	default:
+		return UnitLabel{}, fmt.Errorf("unknown unit %v", unit)
+	}
+}
+
+// Unit returns the unit that is labelled.
+func (l UnitLabel) Unit() Unit {
+	return l.unit
+}
+
+// String returns the standard label for the unit, eg "kg1ha-1".
+func (l UnitLabel) String() string {
+	if l.unit == nil {
+		return ""
+	}
+	return l.unit.String()
+}
+
+// WithSuperscript returns the unit symbol with superscript exponents, eg "m²" or "kg·ha⁻¹".
+func (l UnitLabel) WithSuperscript() string {
+	if l.unit == nil {
+		return ""
+	}
+	return symbolWithSuperscript(l.unit, 1)
+}
+
+// FullWord returns the name of the unit in words, eg "kilogram", or "kilograms per hectare" for a ratio unit.
+func (l UnitLabel) FullWord() string {
+	if l.unit == nil {
+		return ""
+	}
+	if n, d, ok := ratioParts(l.unit); ok {
+		return pluralName(n) + " per " + singularName(d)
+	}
+	if c, ok := l.unit.(CompoundUnit); ok {
+		return compoundName(c, true)
+	}
+	return singularName(l.unit)
+}
+
+// FullWordFor returns the name of the unit in words for the value, pluralised unless the value is 1 or -1, eg
+// "1 kilogram", "2.5 kilograms" or "1 kilogram per hectare".
+func (l UnitLabel) FullWordFor(value float64) string {
+	if l.unit == nil {
+		return ""
+	}
+	name := pluralName
+	if value == 1 || value == -1 {
+		name = singularName
+	}
+	if n, d, ok := ratioParts(l.unit); ok {
+		return name(n) + " per " + singularName(d)
+	}
+	return name(l.unit)
+}
+
+// ratioParts returns the numerator and denominator of a ratio unit, and false if u is not a ratio unit.
+func ratioParts(u Unit) (Unit, Unit, bool) {
+	switch v := u.(type) {
+	case RatioUnit:
+		return v.Numerator, v.Denominator, true
+	case MassAreaRatioUnit:
+		return v.Numerator, v.Denominator, true
+	case VolumeAreaRatioUnit:
+		return v.Numerator, v.Denominator, true
+	}
+	return nil, nil, false
+}
+
+// symbolWithSuperscript returns the symbol of u raised to the power exp, with superscript exponents.
+func symbolWithSuperscript(u Unit, exp int) string {
+	if n, d, ok := ratioParts(u); ok {
+		if exp != 1 {
+			return "(" + symbolWithSuperscript(u, 1) + ")" + superscript(exp)
+		}
+		return symbolWithSuperscript(n, 1) + "·" + symbolWithSuperscript(d, -1)
+	}
+	if c, ok := u.(CompoundUnit); ok {
+		xs := make([]string, len(c.Terms))
+		for i, t := range c.Terms {
+			xs[i] = symbolWithSuperscript(t.Unit, t.Exponent*exp)
+		}
+		return strings.Join(xs, "·")
+	}
+	if s, ok := u.(ScaledUnit); ok {
+		if exp != 1 {
+			return "(" + symbolWithSuperscript(u, 1) + ")" + superscript(exp)
+		}
+		return ratString(s.Scale) + " " + symbolWithSuperscript(s.Unit, 1)
+	}
+	base, power := splitExponent(u.String())
+	if power*exp == 1 {
+		return base
+	}
+	return base + superscript(power*exp)
+}
+
+// splitExponent splits a standard label such as "m2" into its base and exponent. Labels without a trailing
+// exponent, such as "ha" or "ac-ft", have an exponent of 1.
+func splitExponent(s string) (string, int) {
+	if len(s) > 1 {
+		switch s[len(s)-1] {
+		case '2':
+			return s[:len(s)-1], 2
+		case '3':
+			return s[:len(s)-1], 3
+		}
+	}
+	return s, 1
+}
+
+// superscript returns the integer n in superscript characters.
+func superscript(n int) string {
+	var b strings.Builder
+	for _, r := range fmt.Sprint(n) {
+		b.WriteRune(superscripts[r])
+	}
+	return b.String()
+}
+
+// singularName returns the full name of a simple unit, or its standard label if it has no name.
+func singularName(u Unit) string {
+	switch v := u.(type) {
+	case AreaUnit:
+		return v.full
+	case LineUnit:
+		return v.full
+	case MassUnit:
+		return v.full
+	case TimeUnit:
+		return v.full
+	case VolumeUnit:
+		return v.full
+	case TemperatureUnit:
+		return v.full
+	case AmountUnit:
+		return v.full
+	case CountUnit:
+		return v.full
+	case FractionUnit:
+		return v.full
+	case ScaledUnit:
+		return ratString(v.Scale) + " " + pluralName(v.Unit)
+	case CompoundUnit:
+		return compoundName(v, false)
+	}
+	if n, d, ok := ratioParts(u); ok {
+		return singularName(n) + " per " + singularName(d)
+	}
+	return u.String()
+}
+
+// pluralName returns the plural of the full name of a unit. Only the last word is pluralised, eg "square feet", and
+// a ratio unit pluralises its numerator, eg "litres per hectare".
+func pluralName(u Unit) string {
+	if n, d, ok := ratioParts(u); ok {
+		return pluralName(n) + " per " + singularName(d)
+	}
+	if c, ok := u.(CompoundUnit); ok {
+		return compoundName(c, true)
+	}
+	name := singularName(u)
+	if _, ok := u.(ScaledUnit); ok || name == u.String() {
+		return name
+	}
+	if p, ok := irregularPlurals[name]; ok {
+		return p
+	}
+	i := strings.LastIndex(name, " ")
+	prefix, last := name[:i+1], name[i+1:]
+	if p, ok := irregularPlurals[last]; ok {
+		return prefix + p
+	}
+	if strings.HasSuffix(last, "ch") || strings.HasSuffix(last, "sh") || strings.HasSuffix(last, "s") {
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// compoundName returns the name of a compound unit, eg "tonnes per hectare per year" or "kilogram metres per second
+// squared". If plural is true the last unit before the first 'per' is pluralised.
+func compoundName(u CompoundUnit, plural bool) string {
+	var numerator, denominator []string
+	last := -1
+	for i, t := range u.Terms {
+		if t.Exponent > 0 {
+			last = i
+		}
+	}
+	for i, t := range u.Terms {
+		name := singularName(t.Unit)
+		if plural && i == last {
+			name = pluralName(t.Unit)
+		}
+		switch e := t.Exponent; {
+		case e == 2 || e == -2:
+			name += " squared"
+		case e == 3 || e == -3:
+			name += " cubed"
+		case e > 3 || e < -3:
+			name += fmt.Sprintf(" to the power %d", abs(e))
+		}
+		if t.Exponent > 0 {
+			numerator = append(numerator, name)
+		} else {
+			denominator = append(denominator, "per "+name)
+		}
+	}
+	return strings.Join(append(numerator, denominator...), " ")
+}
@@ -0,0 +1,125 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUnitLabel(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     any
+		want    string
+		wantErr bool
+	}{
+		"unit":          {arg: Kilogram, want: "kg"},
+		"ratio unit":    {arg: MassAreaRatioUnit{Kilogram, Hectare}, want: "kg1ha-1"},
+		"string label":  {arg: "gal/ac", want: "gal1ac-1"},
+		"unknown label": {arg: "xx", wantErr: true},
+		"empty label":   {arg: "", wantErr: true},
+		"nil":           {arg: nil, wantErr: true},
+		"not a unit":    {arg: 42, wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewUnitLabel(c.arg)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			assert.Equal(t, c.want, got.String())
+		})
+	}
+}
+
+func TestUnitLabel(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg             Unit
+		wantSuperscript string
+		wantFullWord    string
+		wantSingular    string
+		wantPlural      string
+	}{
+		"kilogram": {
+			arg:             Kilogram,
+			wantSuperscript: "kg",
+			wantFullWord:    "kilogram",
+			wantSingular:    "kilogram",
+			wantPlural:      "kilograms",
+		},
+		"square metre": {
+			arg:             SquareMetre,
+			wantSuperscript: "m²",
+			wantFullWord:    "square metre",
+			wantSingular:    "square metre",
+			wantPlural:      "square metres",
+		},
+		"cubic foot": {
+			arg:             CubicFoot,
+			wantSuperscript: "ft³",
+			wantFullWord:    "cubic foot",
+			wantSingular:    "cubic foot",
+			wantPlural:      "cubic feet",
+		},
+		"inch": {
+			arg:             Inch,
+			wantSuperscript: "in",
+			wantFullWord:    "inch",
+			wantSingular:    "inch",
+			wantPlural:      "inches",
+		},
+		"acre inch": {
+			arg:             AcreInch,
+			wantSuperscript: "ac-in",
+			wantFullWord:    "acre inch",
+			wantSingular:    "acre inch",
+			wantPlural:      "acre inches",
+		},
+		"ounce mass": {
+			arg:             OunceMass,
+			wantSuperscript: "ozm",
+			wantFullWord:    "ounce mass",
+			wantSingular:    "ounce mass",
+			wantPlural:      "ounces mass",
+		},
+		"mass area ratio": {
+			arg:             MassAreaRatioUnit{Kilogram, Hectare},
+			wantSuperscript: "kg·ha⁻¹",
+			wantFullWord:    "kilograms per hectare",
+			wantSingular:    "kilogram per hectare",
+			wantPlural:      "kilograms per hectare",
+		},
+		"volume area ratio with exponent": {
+			arg:             VolumeAreaRatioUnit{CubicMetre, SquareMetre},
+			wantSuperscript: "m³·m⁻²",
+			wantFullWord:    "cubic metres per square metre",
+			wantSingular:    "cubic metre per square metre",
+			wantPlural:      "cubic metres per square metre",
+		},
+		"dilution rate": {
+			arg:             RatioUnit{Millilitre, Litre},
+			wantSuperscript: "ml·l⁻¹",
+			wantFullWord:    "millilitres per litre",
+			wantSingular:    "millilitre per litre",
+			wantPlural:      "millilitres per litre",
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			l, err := NewUnitLabel(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantSuperscript, l.WithSuperscript())
+			assert.Equal(t, c.wantFullWord, l.FullWord())
+			assert.Equal(t, c.wantSingular, l.FullWordFor(1))
+			assert.Equal(t, c.wantPlural, l.FullWordFor(2.5))
+			assert.Equal(t, c.wantPlural, l.FullWordFor(0))
+		})
+	}
+}
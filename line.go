@@ -29,7 +29,7 @@ type LineUnit struct {
 	full       string
 	fancy      string
 	aliases    []string
-	conversion float64
+	conversion factor
 }
 
 // String returns the string representation of the base line unit.
@@ -72,7 +72,7 @@ var Millimetre = LineUnit{
 		"millimeters",
 		"millimetres",
 	},
-	conversion: 0.001,
+	conversion: exactFactor("0.001"),
 }
 
 var Centimetre = LineUnit{
@@ -84,7 +84,7 @@ var Centimetre = LineUnit{
 		"centimeters",
 		"centimetres",
 	},
-	conversion: 0.01,
+	conversion: exactFactor("0.01"),
 }
 
 var Metre = LineUnit{
@@ -95,8 +95,11 @@ var Metre = LineUnit{
 		"meter",
 		"meters",
 		"metres",
+		"m of row",
+		"metre of row",
+		"metres of row",
 	},
-	conversion: 1,
+	conversion: exactFactor("1"),
 }
 
 var Kilometre = LineUnit{
@@ -108,7 +111,7 @@ var Kilometre = LineUnit{
 		"kilometers",
 		"kilometres",
 	},
-	conversion: 1000,
+	conversion: exactFactor("1000"),
 }
 
 var Inch = LineUnit{
@@ -118,7 +121,7 @@ var Inch = LineUnit{
 	aliases: []string{
 		"inches",
 	},
-	conversion: 0.0254,
+	conversion: exactFactor("0.0254"), // international inch, 1959
 }
 
 var Foot = LineUnit{
@@ -127,8 +130,11 @@ var Foot = LineUnit{
 	fancy: "foot",
 	aliases: []string{
 		"feet",
+		"ft of row",
+		"foot of row",
+		"feet of row",
 	},
-	conversion: 0.3048,
+	conversion: exactFactor("0.3048"), // 12 in
 }
 
 var Yard = LineUnit{
@@ -138,7 +144,7 @@ var Yard = LineUnit{
 	aliases: []string{
 		"yards",
 	},
-	conversion: 0.9144,
+	conversion: exactFactor("0.9144"), // 3 ft
 }
 
 var Mile = LineUnit{
@@ -148,13 +154,13 @@ var Mile = LineUnit{
 	aliases: []string{
 		"miles",
 	},
-	conversion: 1609.34,
+	conversion: exactFactor("1609.344"), // 5280 ft
 }
 
 // lineUnitFromString returns the first lineUnit that matches the search string, or nil if no match is found.
 func lineUnitFromString(s string) (LineUnit, error) {
-	for _, u := range lineUnits {
-		if u.Matches(s) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(LineUnit); ok {
 			return u, nil
 		}
 	}
@@ -170,8 +176,13 @@ type LineMeasurement struct {
 // To converts a line measurement to the specified unit.
 func (m LineMeasurement) To(unit LineUnit) LineMeasurement {
 	if m.Value != 0 {
-		m.Value = (m.Value * m.Unit.conversion) / unit.conversion
+		m.Value = scaleValue(m.Value, m.Unit.conversion, unit.conversion)
 	}
 	m.Unit = unit
 	return m
 }
+
+// UnitAs returns the unit of a line measurement in the format f, eg "ft" in SlashFormat.
+func (m LineMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(m.Unit, f)
+}
@@ -0,0 +1,170 @@
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Locale is a language tag that determines the decimal and thousands separators used for numbers.
+type Locale string
+
+const (
+	LocaleEnUS Locale = "en-US"
+	LocaleEnAU Locale = "en-AU"
+	LocaleDE   Locale = "de"
+	LocaleFR   Locale = "fr"
+	LocaleES   Locale = "es"
+	LocalePtBR Locale = "pt-BR"
+)
+
+// String returns the string representation of the locale.
+func (l Locale) String() string {
+	return string(l)
+}
+
+// numberSymbols are the separators that a locale uses when writing numbers.
+type numberSymbols struct {
+	decimal string
+	group   string
+	// groupAlternatives are other separators accepted as the thousands separator when parsing.
+	groupAlternatives []string
+	// minGrouping is the minimum number of digits in the integer part before thousands are grouped, eg Spanish
+	// writes 1234 but 12.345.
+	minGrouping int
+	pattern     *regexp.Regexp
+}
+
+// localeSymbols are the number symbols for each supported locale, from the Unicode CLDR.
+var localeSymbols = map[Locale]*numberSymbols{
+	LocaleEnUS: newNumberSymbols(".", ",", 4),
+	LocaleEnAU: newNumberSymbols(".", ",", 4),
+	LocaleDE:   newNumberSymbols(",", ".", 4),
+	LocaleFR:   newNumberSymbols(",", "\u202f", 4, "\u00a0", " "), // narrow no-break space
+	LocaleES:   newNumberSymbols(",", ".", 5),
+	LocalePtBR: newNumberSymbols(",", ".", 4),
+}
+
+// newNumberSymbols returns the number symbols with a pattern for matching a number at the start of a quantity.
+func newNumberSymbols(decimal, group string, minGrouping int, groupAlternatives ...string) *numberSymbols {
+	groups := make([]string, 0, len(groupAlternatives)+1)
+	for _, g := range append([]string{group}, groupAlternatives...) {
+		groups = append(groups, regexp.QuoteMeta(g))
+	}
+	g := "(?:" + strings.Join(groups, "|") + ")"
+	d := regexp.QuoteMeta(decimal)
+	number := fmt.Sprintf(`[+-]?(?:\d{1,3}(?:%s\d{3})+|\d+)(?:%s\d+)?(?:[eE][+-]?\d+)?`, g, d)
+	return &numberSymbols{
+		decimal:           decimal,
+		group:             group,
+		groupAlternatives: groupAlternatives,
+		minGrouping:       minGrouping,
+		pattern:           regexp.MustCompile(`^(` + number + `)\s*(.+)$`),
+	}
+}
+
+// symbolsFor returns the number symbols for the locale. A regional locale that is not supported falls back to its
+// language, eg de-AT uses de.
+func symbolsFor(loc Locale) (*numberSymbols, error) {
+	tag := strings.ReplaceAll(string(loc), "_", "-")
+	for l, s := range localeSymbols {
+		if strings.EqualFold(string(l), tag) {
+			return s, nil
+		}
+	}
+	language, _, _ := strings.Cut(tag, "-")
+	for l, s := range localeSymbols {
+		if strings.EqualFold(string(l), language) {
+			return s, nil
+		}
+	}
+	// Regional variants of supported regional locales, eg en-GB and pt-PT
+	for _, l := range []Locale{LocaleEnUS, LocalePtBR} {
+		if base, _, _ := strings.Cut(string(l), "-"); strings.EqualFold(base, language) {
+			return localeSymbols[l], nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported locale: %s", loc)
+}
+
+// ParseNumber parses a number written with the decimal and thousands separators of the locale, eg "1.234,5" in de.
+// Thousands separators are optional but must separate groups of three digits in the integer part.
+func ParseNumber(s string, loc Locale) (float64, error) {
+	sym, err := symbolsFor(loc)
+	if err != nil {
+		return 0, err
+	}
+	return sym.parse(strings.TrimSpace(s))
+}
+
+// ParseQuantityLocale parses a value with a unit, such as "1.234,5 kg/ha", where the value is written with the
+// separators of the locale. Units are resolved as for ParseQuantity.
+func ParseQuantityLocale(s string, loc Locale) (Quantity, error) {
+	sym, err := symbolsFor(loc)
+	if err != nil {
+		return Quantity{}, err
+	}
+	m := sym.pattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Quantity{}, fmt.Errorf("cannot parse quantity: %s", s)
+	}
+	v, err := sym.parse(m[1])
+	if err != nil {
+		return Quantity{}, fmt.Errorf("cannot parse quantity %s: %w", s, err)
+	}
+	return NewQuantity(v, strings.TrimSpace(m[2]))
+}
+
+// parse parses a number written with the locale symbols.
+func (sym *numberSymbols) parse(s string) (float64, error) {
+	mantissa, exponent := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent = s[:i], s[i:]
+	}
+	whole, fraction, hasDecimal := strings.Cut(mantissa, sym.decimal)
+	for _, g := range sym.groupAlternatives {
+		whole = strings.ReplaceAll(whole, g, sym.group)
+	}
+	if strings.Contains(whole, sym.group) {
+		groups := strings.Split(strings.TrimLeft(whole, "+-"), sym.group)
+		for i, g := range groups {
+			if (i == 0 && (len(g) == 0 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+				return 0, fmt.Errorf("invalid number %s: misplaced thousands separator", s)
+			}
+		}
+		whole = strings.ReplaceAll(whole, sym.group, "")
+	}
+	n := whole
+	if hasDecimal {
+		n += "." + fraction
+	}
+	v, err := strconv.ParseFloat(n+exponent, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %s", s)
+	}
+	return v, nil
+}
+
+// format applies the locale symbols to a number formatted with a '.' decimal separator and no grouping.
+func (sym *numberSymbols) format(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, fraction, hasDecimal := strings.Cut(s, ".")
+	if len(whole) >= sym.minGrouping {
+		var b strings.Builder
+		for i, r := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 {
+				b.WriteString(sym.group)
+			}
+			b.WriteRune(r)
+		}
+		whole = b.String()
+	}
+	if hasDecimal {
+		return sign + whole + sym.decimal + fraction
+	}
+	return sign + whole
+}
@@ -0,0 +1,131 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNumber(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     string
+		locale  Locale
+		want    float64
+		wantErr bool
+	}{
+		"en-US":                       {arg: "1,234.5", locale: LocaleEnUS, want: 1234.5},
+		"en-AU":                       {arg: "1,234,567.25", locale: LocaleEnAU, want: 1234567.25},
+		"de":                          {arg: "1.234,5", locale: LocaleDE, want: 1234.5},
+		"de without grouping":         {arg: "1234,5", locale: LocaleDE, want: 1234.5},
+		"fr narrow no-break space":    {arg: "1 234,5", locale: LocaleFR, want: 1234.5},
+		"fr no-break space":           {arg: "1 234,5", locale: LocaleFR, want: 1234.5},
+		"fr space":                    {arg: "1 234,5", locale: LocaleFR, want: 1234.5},
+		"es":                          {arg: "12.345,5", locale: LocaleES, want: 12345.5},
+		"pt-BR":                       {arg: "-1.234,5", locale: LocalePtBR, want: -1234.5},
+		"regional fallback de-AT":     {arg: "1.234,5", locale: "de-AT", want: 1234.5},
+		"regional fallback pt-PT":     {arg: "1.234,5", locale: "pt_PT", want: 1234.5},
+		"case insensitive":            {arg: "1.234,5", locale: "PT-br", want: 1234.5},
+		"exponent":                    {arg: "1,5e3", locale: LocaleDE, want: 1500},
+		"decimal comma in en-US":      {arg: "1.234,5", locale: LocaleEnUS, wantErr: true},
+		"misplaced thousands":         {arg: "12.34,5", locale: LocaleDE, wantErr: true},
+		"unsupported locale":          {arg: "1", locale: "xx", wantErr: true},
+		"not a number":                {arg: "abc", locale: LocaleDE, wantErr: true},
+		"leading thousands separator": {arg: ".234,5", locale: LocaleDE, wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseNumber(c.arg, c.locale)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestParseQuantityLocale(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     string
+		locale  Locale
+		want    Quantity
+		wantErr bool
+	}{
+		"de": {
+			arg:    "1.234,5 kg/ha",
+			locale: LocaleDE,
+			want:   Quantity{1234.5, MassAreaRatioUnit{Kilogram, Hectare}, MassAreaRatioDimension},
+		},
+		"fr": {
+			arg:    "1 234,5 l/ha",
+			locale: LocaleFR,
+			want:   Quantity{1234.5, VolumeAreaRatioUnit{Litre, Hectare}, VolumeAreaRatioDimension},
+		},
+		"pt-BR no space": {
+			arg:    "93,5kg",
+			locale: LocalePtBR,
+			want:   Quantity{93.5, Kilogram, MassDimension},
+		},
+		"en-US": {
+			arg:    "1,234.5 lb/ac",
+			locale: LocaleEnUS,
+			want:   Quantity{1234.5, MassAreaRatioUnit{Pound, Acre}, MassAreaRatioDimension},
+		},
+		"unknown unit":       {arg: "1,5 xx", locale: LocaleDE, wantErr: true},
+		"unsupported locale": {arg: "1 kg", locale: "xx", wantErr: true},
+		"no value":           {arg: "kg", locale: LocaleDE, wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseQuantityLocale(c.arg, c.locale)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestNumberFormat_FormatLocale(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		format NumberFormat
+		arg    float64
+		want   string
+	}{
+		"no locale":        {format: NumberFormat{DecimalPlaces: 1}, arg: 1234.5, want: "1234.5"},
+		"en-US":            {format: NumberFormat{DecimalPlaces: 1, Locale: LocaleEnUS}, arg: 1234567.5, want: "1,234,567.5"},
+		"en-AU":            {format: NumberFormat{DecimalPlaces: 2, Locale: LocaleEnAU}, arg: 999.5, want: "999.50"},
+		"de":               {format: NumberFormat{DecimalPlaces: 1, Locale: LocaleDE}, arg: 1234.5, want: "1.234,5"},
+		"de negative":      {format: NumberFormat{DecimalPlaces: 1, Locale: LocaleDE}, arg: -1234.5, want: "-1.234,5"},
+		"fr":               {format: NumberFormat{DecimalPlaces: 2, Locale: LocaleFR}, arg: 1234.5, want: "1 234,50"},
+		"es four digits":   {format: NumberFormat{DecimalPlaces: 1, Locale: LocaleES}, arg: 1234.5, want: "1234,5"},
+		"es five digits":   {format: NumberFormat{DecimalPlaces: 1, Locale: LocaleES}, arg: 12345.5, want: "12.345,5"},
+		"pt-BR whole":      {format: NumberFormat{Locale: LocalePtBR}, arg: 1234567, want: "1.234.567"},
+		"significant figs": {format: NumberFormat{SignificantFigures: 3, Locale: LocaleDE}, arg: 93.5396, want: "93,5"},
+		"unsupported":      {format: NumberFormat{DecimalPlaces: 1, Locale: "xx"}, arg: 1234.5, want: "1234.5"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.want, c.format.Format(c.arg))
+		})
+	}
+}
+
+func TestMeasurement_FormatLocale(t *testing.T) {
+	t.Parallel()
+	de := NumberFormat{DecimalPlaces: 2, Locale: LocaleDE}
+	assert.Equal(t, "1.234,50 kg/ha", NewMassAreaRatioMeasure(1234.5, Kilogram, Hectare).Format(de))
+	assert.Equal(t, "93,50 l/ha", NewVolumeAreaMeasurement(93.5, Litre, Hectare).Format(de))
+	assert.Equal(t, "2.000,00 kg", MassMeasurement{2000, Kilogram}.Format(de))
+	assert.Equal(t, 1234.5, NumberFormat{DecimalPlaces: 1, Locale: LocaleDE}.Round(1234.54))
+}
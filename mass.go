@@ -17,6 +17,10 @@ const (
 	OunceMassStandard Mass = "ozm"
 	StoneStandard     Mass = "st"
 	TonStandard       Mass = "ton"
+	QuintalStandard   Mass = "q"
+
+	HundredweightStandard Mass = "cwt"      // US hundredweight
+	LongTonStandard       Mass = "long ton" // not in the unit tables
 )
 
 // String returns the string representation of the mass unit.
@@ -30,7 +34,7 @@ type MassUnit struct {
 	full       string
 	fancy      string
 	aliases    []string
-	conversion float64
+	conversion factor
 }
 
 // String returns the string representation of the base mass unit.
@@ -63,6 +67,8 @@ var massUnits = []MassUnit{
 	OunceMass,
 	Stone,
 	Ton,
+	Quintal,
+	Hundredweight,
 }
 
 var Milligram = MassUnit{
@@ -75,7 +81,7 @@ var Milligram = MassUnit{
 		"mil",
 		"mils",
 	},
-	conversion: 0.001,
+	conversion: exactFactor("0.001"),
 }
 
 var Decigram = MassUnit{
@@ -85,7 +91,7 @@ var Decigram = MassUnit{
 	aliases: []string{
 		"decigrams",
 	},
-	conversion: 0.1,
+	conversion: exactFactor("0.1"),
 }
 
 var Gram = MassUnit{
@@ -95,7 +101,7 @@ var Gram = MassUnit{
 	aliases: []string{
 		"grams",
 	},
-	conversion: 1,
+	conversion: exactFactor("1"),
 }
 
 var Kilogram = MassUnit{
@@ -107,7 +113,7 @@ var Kilogram = MassUnit{
 		"kilo",
 		"kilos",
 	},
-	conversion: 1000,
+	conversion: exactFactor("1000"),
 }
 
 var Tonne = MassUnit{
@@ -121,7 +127,7 @@ var Tonne = MassUnit{
 		"metric tonne",
 		"metric tonnes",
 	},
-	conversion: 1000000,
+	conversion: exactFactor("1000000"),
 }
 
 var OunceMass = MassUnit{
@@ -133,7 +139,7 @@ var OunceMass = MassUnit{
 		"ounces",
 		"oz",
 	},
-	conversion: 28.3495,
+	conversion: exactFactor("28.349523125"), // 1/16 lb
 }
 
 var Pound = MassUnit{
@@ -144,7 +150,7 @@ var Pound = MassUnit{
 		"pounds",
 		"lbs",
 	},
-	conversion: 453.592,
+	conversion: exactFactor("453.59237"), // international avoirdupois pound, 1959
 }
 
 var Stone = MassUnit{
@@ -154,7 +160,7 @@ var Stone = MassUnit{
 	aliases: []string{
 		"stones",
 	},
-	conversion: 6350.29,
+	conversion: exactFactor("6350.29318"), // 14 lb
 }
 
 var Ton = MassUnit{
@@ -166,13 +172,50 @@ var Ton = MassUnit{
 		"short ton",
 		"short tons",
 	},
-	conversion: 907185,
+	conversion: exactFactor("907184.74"), // short ton, 2000 lb
+}
+
+var Quintal = MassUnit{
+	unit:  QuintalStandard,
+	full:  "quintal",
+	fancy: string(QuintalStandard),
+	aliases: []string{
+		"quintals",
+	},
+	// metric quintal, 100 kg. qq is not an alias because it usually means the 46 kg Spanish quintal.
+	conversion: exactFactor("100000"),
+}
+
+var Hundredweight = MassUnit{
+	unit:  HundredweightStandard,
+	full:  "hundredweight",
+	fancy: string(HundredweightStandard),
+	aliases: []string{
+		"hundredweights",
+		"short hundredweight",
+		"short hundredweights",
+	},
+	conversion: exactFactor("45359.237"), // short hundredweight, 100 lb
+}
+
+// LongTon is the UK ton of 2240 lb. It is not in the unit tables, since ton is read as the short ton, and is one of
+// the candidates that AmbiguousLabels and UnitFromLabelStrict report for ton.
+var LongTon = MassUnit{
+	unit:  LongTonStandard,
+	full:  "long ton",
+	fancy: string(LongTonStandard),
+	aliases: []string{
+		"long tons",
+		"imperial ton",
+		"imperial tons",
+	},
+	conversion: exactFactor("1016046.9088"), // 2240 lb
 }
 
 // massUnitFromString returns the first mass unit that matches s.
 func massUnitFromString(s string) (MassUnit, error) {
-	for _, u := range massUnits {
-		if u.Matches(s) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(MassUnit); ok {
 			return u, nil
 		}
 	}
@@ -188,8 +231,13 @@ type MassMeasurement struct {
 // To converts a mass measurement To the specified unit.
 func (m MassMeasurement) To(unit MassUnit) MassMeasurement {
 	if m.Value != 0 {
-		m.Value = (m.Value * m.Unit.conversion) / unit.conversion
+		m.Value = scaleValue(m.Value, m.Unit.conversion, unit.conversion)
 	}
 	m.Unit = unit
 	return m
 }
+
+// UnitAs returns the unit of a mass measurement in the format f, eg "kg" in SlashFormat.
+func (m MassMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(m.Unit, f)
+}
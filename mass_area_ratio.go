@@ -96,13 +96,11 @@ func NewMassAreaRatioMeasureFromUnitString(v float64, compoundUnit string) (Mass
 
 // To converts the MassAreaRatioMeasure to the specified mass and area units
 func (mr MassAreaRatioMeasure) To(toMassUnit MassUnit, toAreaUnit AreaUnit) MassAreaRatioMeasure {
-	toMass := mr.MassMeasurement.To(toMassUnit)
-	toArea := AreaMeasurement{Value: 1, Unit: mr.unitArea}.To(toAreaUnit)
-	toMass.Value = toMass.Value / toArea.Value
-	return MassAreaRatioMeasure{
-		MassMeasurement: toMass,
-		unitArea:        toAreaUnit,
+	v := mr.MassMeasurement.Value
+	if v != 0 {
+		v = scaleRatioValue(v, mr.MassMeasurement.Unit.conversion, mr.unitArea.conversion, toMassUnit.conversion, toAreaUnit.conversion)
 	}
+	return NewMassAreaRatioMeasure(v, toMassUnit, toAreaUnit)
 }
 
 // Value returns the value of the MassAreaRatioMeasure
@@ -115,3 +113,8 @@ func (mr MassAreaRatioMeasure) Value() float64 {
 func (mr MassAreaRatioMeasure) Unit() (string, error) {
 	return joinCompoundUnit(mr.MassMeasurement.Unit.String(), mr.unitArea.standard.String())
 }
+
+// UnitAs returns the unit of the MassAreaRatioMeasure in the format f, eg "kg/ha" in SlashFormat.
+func (mr MassAreaRatioMeasure) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(MassAreaRatioUnit{Numerator: mr.MassMeasurement.Unit, Denominator: mr.unitArea}, f)
+}
@@ -55,10 +55,11 @@ func TestMassMeasurementTo(t *testing.T) {
 		"1 dg To kg":      {arg: MassMeasurement{1, Decigram}, want: MassMeasurement{0.0001, Kilogram}},
 		"1 mg To kg":      {arg: MassMeasurement{1, Milligram}, want: MassMeasurement{0.000001, Kilogram}},
 		"1 t To kg":       {arg: MassMeasurement{1, Tonne}, want: MassMeasurement{1000, Kilogram}},
-		"1 ton To kg":     {arg: MassMeasurement{1, Ton}, want: MassMeasurement{907.185, Kilogram}},
+		"1 ton To kg":     {arg: MassMeasurement{1, Ton}, want: MassMeasurement{907.18474, Kilogram}},
+		"1 q To kg":       {arg: MassMeasurement{1, Quintal}, want: MassMeasurement{100, Kilogram}},
 		"1 lb To kg":      {arg: MassMeasurement{1, Pound}, want: MassMeasurement{0.453592, Kilogram}},
 		"1 ozm To kg":     {arg: MassMeasurement{1, OunceMass}, want: MassMeasurement{0.0283495, Kilogram}},
-		"1 st To kg":      {arg: MassMeasurement{1, Stone}, want: MassMeasurement{6.35029, Kilogram}},
+		"1 st To kg":      {arg: MassMeasurement{1, Stone}, want: MassMeasurement{6.35029318, Kilogram}},
 		"453.592 g To lb": {arg: MassMeasurement{453.592, Gram}, want: MassMeasurement{1, Pound}},
 	}
 
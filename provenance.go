@@ -0,0 +1,316 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Provenance is the source of a conversion factor, for methodology documentation. Reference is a URL or the name of
+// a standard, Date is the date or year of the source, and Jurisdiction is where the factor applies, eg US or
+// international.
+type Provenance struct {
+	Source       string
+	Reference    string
+	Date         string
+	Jurisdiction string
+}
+
+// String returns the source, reference, date and jurisdiction, eg "BIPM, SI Brochure, 9th edition, 2019-05-20,
+// international".
+func (p Provenance) String() string {
+	s := p.Source
+	for _, x := range []string{p.Reference, p.Date, p.Jurisdiction} {
+		if x != "" {
+			s += ", " + x
+		}
+	}
+	return s
+}
+
+// FactorMetadata is a conversion factor with its exact value in Unit and its provenance. Unit factors are named
+// unit/label, eg unit/gal, and crop factors bushel/crop or bale/crop, eg bushel/corn.
+type FactorMetadata struct {
+	Name       string
+	Value      string
+	Unit       string
+	Provenance Provenance
+}
+
+// The sources of the unit and crop factors. The package has no density factors.
+var (
+	siBrochure = Provenance{
+		Source:       "BIPM",
+		Reference:    "SI Brochure, 9th edition",
+		Date:         "2019-05-20",
+		Jurisdiction: "international",
+	}
+	yardPoundAgreement = Provenance{
+		Source:       "International yard and pound agreement",
+		Reference:    "NIST Handbook 44, Appendix C",
+		Date:         "1959-07-01",
+		Jurisdiction: "international",
+	}
+	usCustomaryVolume = Provenance{
+		Source:       "NIST",
+		Reference:    "NIST Handbook 44, Appendix C",
+		Date:         "1959-07-01",
+		Jurisdiction: "US",
+	}
+	usAcre = Provenance{
+		Source:       "NIST",
+		Reference:    "85 FR 62698, deprecation of the US survey foot",
+		Date:         "2023-01-01",
+		Jurisdiction: "US",
+	}
+	ukStone = Provenance{
+		Source:       "UK legislation",
+		Reference:    "Weights and Measures Act 1985, Schedule 1",
+		Date:         "1985",
+		Jurisdiction: "UK",
+	}
+	metricQuintal = Provenance{
+		Source:       "Metric quintal",
+		Reference:    "100 kg by definition",
+		Jurisdiction: "international",
+	}
+	isoWeek = Provenance{
+		Source:       "ISO",
+		Reference:    "ISO 8601-1:2019",
+		Date:         "2019",
+		Jurisdiction: "international",
+	}
+	calendarConvention = Provenance{
+		Source:    "Package convention",
+		Reference: "a year is 365 days and a month is 1/12 of a year",
+	}
+	nistFahrenheit = Provenance{
+		Source:       "NIST",
+		Reference:    "NIST Special Publication 811",
+		Date:         "2008",
+		Jurisdiction: "US",
+	}
+	exactDefinition = Provenance{
+		Source:       "Exact by definition",
+		Jurisdiction: "international",
+	}
+	cottonBaleVolume = Provenance{
+		Source:       "National Cotton Council of America",
+		Reference:    "https://www.cotton.org/tech/bale/bale-description.cfm",
+		Jurisdiction: "US",
+	}
+	usdaBushelWeights = Provenance{
+		Source:       "USDA Economic Research Service",
+		Reference:    "Agricultural Handbook No. 697, Weights, Measures, and Conversion Factors for Agricultural Commodities",
+		Date:         "1992-06",
+		Jurisdiction: "US",
+	}
+	cottonBaleWeight = Provenance{
+		Source:       "Wikipedia",
+		Reference:    "https://en.wikipedia.org/wiki/Cotton_bale",
+		Jurisdiction: "US",
+	}
+	packageBaseline = Provenance{
+		Source:    "Package convention",
+		Reference: "factors rounded to about six significant figures, used before factor set v1",
+	}
+)
+
+// unitSources maps the standard label of each simple unit to the provenance of its factor.
+var unitSources = map[string]Provenance{
+	// area
+	SquareCentimetreStandard.String(): siBrochure,
+	SquareMetreStandard.String():      siBrochure,
+	SquareKilometreStandard.String():  siBrochure,
+	HectareStandard.String():          siBrochure,
+	SquareInchStandard.String():       yardPoundAgreement,
+	SquareFootStandard.String():       yardPoundAgreement,
+	SquareYardStandard.String():       yardPoundAgreement,
+	SquareMileStandard.String():       yardPoundAgreement,
+	AcreStandard.String():             usAcre,
+	// line
+	MillimetreStandard.String(): siBrochure,
+	CentimetreStandard.String(): siBrochure,
+	MetreStandard.String():      siBrochure,
+	KilometreStandard.String():  siBrochure,
+	InchStandard.String():       yardPoundAgreement,
+	FootStandard.String():       yardPoundAgreement,
+	YardStandard.String():       yardPoundAgreement,
+	MileStandard.String():       yardPoundAgreement,
+	// mass
+	MilligramStandard.String(): siBrochure,
+	DecigramStandard.String():  siBrochure,
+	GramStandard.String():      siBrochure,
+	KilogramStandard.String():  siBrochure,
+	TonneStandard.String():     siBrochure,
+	PoundStandard.String():     yardPoundAgreement,
+	OunceMassStandard.String(): yardPoundAgreement,
+	StoneStandard.String():     ukStone,
+	TonStandard.String():       yardPoundAgreement,
+	QuintalStandard.String():   metricQuintal,
+
+	HundredweightStandard.String(): yardPoundAgreement,
+	// time
+	SecondStandard.String(): siBrochure,
+	MinuteStandard.String(): siBrochure,
+	HourStandard.String():   siBrochure,
+	DayStandard.String():    siBrochure,
+	WeekStandard.String():   isoWeek,
+	MonthStandard.String():  calendarConvention,
+	YearStandard.String():   calendarConvention,
+	// volume
+	MicrolitreStandard.String():      siBrochure,
+	MillilitreStandard.String():      siBrochure,
+	CentilitreStandard.String():      siBrochure,
+	DecilitreStandard.String():       siBrochure,
+	LitreStandard.String():           siBrochure,
+	KilolitreStandard.String():       siBrochure,
+	DecalitreStandard.String():       siBrochure,
+	HectolitreStandard.String():      siBrochure,
+	MegalitreStandard.String():       siBrochure,
+	CubicCentimetreStandard.String(): siBrochure,
+	CubicMetreStandard.String():      siBrochure,
+	GallonStandard.String():          usCustomaryVolume,
+	FluidOunceStandard.String():      usCustomaryVolume,
+	QuartStandard.String():           usCustomaryVolume,
+	PintStandard.String():            usCustomaryVolume,
+	CubicInchStandard.String():       yardPoundAgreement,
+	CubicFootStandard.String():       yardPoundAgreement,
+	CubicYardStandard.String():       yardPoundAgreement,
+	AcreFootStandard.String():        usAcre,
+	AcreInchStandard.String():        usAcre,
+	BushelStandard.String():          usCustomaryVolume,
+	BaleStandard.String():            cottonBaleVolume,
+	// temperature
+	KelvinStandard.String():     siBrochure,
+	CelsiusStandard.String():    siBrochure,
+	FahrenheitStandard.String(): nistFahrenheit,
+	// amount
+	MicromoleStandard.String(): siBrochure,
+	MillimoleStandard.String(): siBrochure,
+	MoleStandard.String():      siBrochure,
+	KilomoleStandard.String():  siBrochure,
+	// count
+	CountStandard.String():    exactDefinition,
+	DozenStandard.String():    exactDefinition,
+	ThousandStandard.String(): exactDefinition,
+	// fraction
+	PercentStandard.String():         exactDefinition,
+	PerMilleStandard.String():        exactDefinition,
+	PartsPerMillionStandard.String(): exactDefinition,
+	PartsPerBillionStandard.String(): exactDefinition,
+}
+
+// UnitProvenance returns the provenance of the factor of the unit with the label, which must be a simple unit such as
+// gal or ac.
+func UnitProvenance(label string) (Provenance, error) {
+	u, err := UnitFromLabel(label)
+	if err != nil {
+		return Provenance{}, err
+	}
+	p, ok := unitSources[u.String()]
+	if !ok {
+		return Provenance{}, fmt.Errorf("unit %s has no provenance, only simple units have a factor", u)
+	}
+	return p, nil
+}
+
+// UnitFactorMetadata returns the factor of each simple unit, in the order of the unit tables. The factors are in the
+// base unit of the dimension, which is m2, m, g, s, l, K, mol, count or 1 for a fraction. They are the factors of the
+// default factor set.
+func UnitFactorMetadata() []FactorMetadata {
+	return DefaultFactorSet().UnitFactorMetadata()
+}
+
+// UnitFactorMetadata returns the factor of each simple unit in the factor set, as the UnitFactorMetadata function
+// does.
+func (s FactorSet) UnitFactorMetadata() []FactorMetadata {
+	var m []FactorMetadata
+	for _, u := range simpleUnits() {
+		f, base := s.unitFactor(u)
+		m = append(m, FactorMetadata{
+			Name:       "unit/" + u.String(),
+			Value:      f.String(),
+			Unit:       base,
+			Provenance: factorProvenance(u, f),
+		})
+	}
+	return m
+}
+
+// CropFactorMetadata returns the bushel and bale weights of the factor set, in grams, sorted by name.
+func (s FactorSet) CropFactorMetadata() []FactorMetadata {
+	var m []FactorMetadata
+	for _, x := range []struct {
+		prefix  string
+		weights map[Crop]factor
+	}{{prefix: "bushel/", weights: s.bushelWeights}, {prefix: "bale/", weights: s.baleWeights}} {
+		for c, f := range x.weights {
+			m = append(m, FactorMetadata{Name: x.prefix + string(c), Value: f.String(), Unit: "g", Provenance: f.source})
+		}
+	}
+	sort.Slice(m, func(i, j int) bool {
+		return m[i].Name < m[j].Name
+	})
+	return m
+}
+
+// AllFactorMetadata returns the unit factors, followed by the crop factors of the default factor set.
+func AllFactorMetadata() []FactorMetadata {
+	return DefaultFactorSet().AllFactorMetadata()
+}
+
+// AllFactorMetadata returns the unit factors, followed by the crop factors of the factor set.
+func (s FactorSet) AllFactorMetadata() []FactorMetadata {
+	return append(s.UnitFactorMetadata(), s.CropFactorMetadata()...)
+}
+
+// unitProvenance returns the provenance of the factors of the simple units in u, which can be a scaled, ratio or
+// compound unit, in the order of its terms.
+func unitProvenance(u Unit) []Provenance {
+	var ps []Provenance
+	for _, t := range unitTerms(u) {
+		v := t.Unit
+		if s, ok := v.(ScaledUnit); ok {
+			v = s.Unit
+		}
+		f, _ := unitFactor(v)
+		if p := factorProvenance(v, f); p != (Provenance{}) {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// factorProvenance returns the provenance of f, the factor of the simple unit u. A factor pinned by a factor set has
+// its own source, otherwise it has the source of the unit table.
+func factorProvenance(u Unit, f factor) Provenance {
+	if f.source != (Provenance{}) {
+		return f.source
+	}
+	return unitSources[u.String()]
+}
+
+// unitFactor returns the factor of a simple unit in its unit table, and the label of the base unit of the table.
+func unitFactor(u Unit) (factor, string) {
+	switch v := u.(type) {
+	case AreaUnit:
+		return v.conversion, SquareMetreStandard.String()
+	case LineUnit:
+		return v.conversion, MetreStandard.String()
+	case MassUnit:
+		return v.conversion, GramStandard.String()
+	case TimeUnit:
+		return v.conversion, SecondStandard.String()
+	case VolumeUnit:
+		return v.conversion, LitreStandard.String()
+	case TemperatureUnit:
+		return v.conversion, KelvinStandard.String()
+	case AmountUnit:
+		return v.conversion, MoleStandard.String()
+	case CountUnit:
+		return v.conversion, CountStandard.String()
+	case FractionUnit:
+		return v.conversion, "1"
+	}
+	return factor{}, ""
+}
@@ -0,0 +1,78 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitProvenance(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		label string
+		want  string
+	}{
+		"si":        {label: "kg", want: "BIPM, SI Brochure, 9th edition, 2019-05-20, international"},
+		"us volume": {label: "gallons", want: "NIST, NIST Handbook 44, Appendix C, 1959-07-01, US"},
+		"acre":      {label: "ac", want: "NIST, 85 FR 62698, deprecation of the US survey foot, 2023-01-01, US"},
+		"bale":      {label: "bale", want: "National Cotton Council of America, https://www.cotton.org/tech/bale/bale-description.cfm, US"},
+		"year":      {label: "yr", want: "Package convention, a year is 365 days and a month is 1/12 of a year"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p, err := UnitProvenance(c.label)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, p.String())
+		})
+	}
+
+	_, err := UnitProvenance("kg/ha")
+	assert.EqualError(t, err, "unit kg1ha-1 has no provenance, only simple units have a factor")
+
+	_, err = UnitProvenance("furlong")
+	assert.Error(t, err)
+}
+
+// TestUnitFactorMetadata checks that every simple unit has a factor with a source.
+func TestUnitFactorMetadata(t *testing.T) {
+	t.Parallel()
+
+	m := UnitFactorMetadata()
+	assert.Len(t, m, len(simpleUnits()))
+	for _, f := range m {
+		assert.NotEmpty(t, f.Value, f.Name)
+		assert.NotEmpty(t, f.Unit, f.Name)
+		assert.NotEmpty(t, f.Provenance.Source, f.Name)
+	}
+	assert.Contains(t, m, FactorMetadata{Name: "unit/gal", Value: "3.785411784", Unit: "l", Provenance: usCustomaryVolume})
+}
+
+func TestFactorSet_CropFactorMetadata(t *testing.T) {
+	t.Parallel()
+
+	m := DefaultFactorSet().CropFactorMetadata()
+	assert.Len(t, m, len(cropBushelsToGrams)+len(cropBalesToGrams))
+	for _, f := range m {
+		assert.NotEmpty(t, f.Provenance.Source, f.Name)
+	}
+	assert.Equal(t, FactorMetadata{Name: "bale/cotton", Value: "226796.185", Unit: "g", Provenance: cottonBaleWeight}, m[0])
+	assert.Contains(t, m, FactorMetadata{Name: "bushel/corn", Value: "25401.17272", Unit: "g", Provenance: usdaBushelWeights})
+
+	assert.Len(t, AllFactorMetadata(), len(simpleUnits())+len(m))
+}
+
+func TestExplanation_Provenance(t *testing.T) {
+	t.Parallel()
+
+	e, err := ExplainCropRate("corn", 10, "t/ha", "bu/ac")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]Provenance{
+		{siBrochure, siBrochure},
+		{usdaBushelWeights},
+		{siBrochure, usAcre},
+	}, [][]Provenance{e.Steps[0].Provenance, e.Steps[1].Provenance, e.Steps[2].Provenance})
+}
@@ -0,0 +1,135 @@
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// strictQuantityPattern is a number in Go float syntax, a single space and a unit label, eg "1.2e3 kg".
+	strictQuantityPattern = regexp.MustCompile(`^([+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?) (\S.*)$`)
+
+	// lenientQuantityPattern is a number that may use ',' or '.' as decimal or thousands separators, optionally
+	// followed by white space, and a unit label, eg "93,5 l/ha" or "5lb".
+	lenientQuantityPattern = regexp.MustCompile(`^([+-]?(?:\d[\d.,]*|[.,]\d+)(?:[eE][+-]?\d+)?)\s*(.+)$`)
+)
+
+// Quantity is a value with a resolved unit and the dimension of that unit.
+type Quantity struct {
+	Value     float64
+	Unit      Unit
+	Dimension Dimension
+}
+
+// NewQuantity returns a Quantity for the value and unit label.
+func NewQuantity(value float64, unit string) (Quantity, error) {
+	u, err := UnitFromLabel(unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{
+		Value:     value,
+		Unit:      u,
+		Dimension: DimensionOf(u),
+	}, nil
+}
+
+// ParseQuantity parses a free-form value with a unit, such as "10 gal/ac", "93,5 l/ha", "1.2e3 kg" or "5lb".
+// It is lenient: white space between the value and unit is optional, the value may use a decimal comma or thousands
+// separators, and an ambiguous unit label resolves in the same way as UnitFromLabel. A single comma followed by
+// three digits, as in "1,234", is treated as a thousands separator unless the integer part is zero.
+// The unit can be a simple unit or a compound unit in slash, exponent or 'per' form.
+func ParseQuantity(s string) (Quantity, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	m := lenientQuantityPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Quantity{}, fmt.Errorf("cannot parse quantity: %s", s)
+	}
+	v, err := parseLenientNumber(m[1])
+	if err != nil {
+		return Quantity{}, fmt.Errorf("cannot parse quantity %s: %w", s, err)
+	}
+	return NewQuantity(v, strings.TrimSpace(m[2]))
+}
+
+// ParseQuantityStrict parses a value with a unit, such as "10 l/ha" or "1.2e3 kg". The value must use Go float
+// syntax with a '.' decimal separator and no thousands separators, it must be separated from the unit by a single
+// space, and the unit label must not be ambiguous, see UnitFromLabelStrict.
+func ParseQuantityStrict(s string) (Quantity, error) {
+	m := strictQuantityPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Quantity{}, fmt.Errorf("cannot parse quantity: %s, expecting a number, a space and a unit", s)
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("cannot parse quantity %s: %w", s, err)
+	}
+	u, err := UnitFromLabelStrict(m[2])
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{
+		Value:     v,
+		Unit:      u,
+		Dimension: DimensionOf(u),
+	}, nil
+}
+
+// To converts the quantity to the specified unit.
+func (q Quantity) To(unit string) (Quantity, error) {
+	v, err := ValueFromTo(q.Value, q.Unit.String(), unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return NewQuantity(v, unit)
+}
+
+// String returns the quantity as a string, eg "10 gal1ac-1".
+func (q Quantity) String() string {
+	if q.Unit == nil {
+		return fmt.Sprint(q.Value)
+	}
+	return fmt.Sprintf("%v %s", q.Value, q.Unit)
+}
+
+// parseLenientNumber parses a number that may use ',' or '.' as the decimal separator, and the other as a thousands
+// separator. If both are present the last one is the decimal separator.
+func parseLenientNumber(s string) (float64, error) {
+	mantissa, exponent := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent = s[:i], s[i:]
+	}
+
+	var decimal, group string
+	commas, dots := strings.Count(mantissa, ","), strings.Count(mantissa, ".")
+	switch {
+	case commas > 0 && dots > 0:
+		if strings.LastIndex(mantissa, ",") > strings.LastIndex(mantissa, ".") {
+			decimal, group = ",", "."
+		} else {
+			decimal, group = ".", ","
+		}
+	case commas > 1:
+		group = ","
+	case commas == 1:
+		whole, fraction, _ := strings.Cut(mantissa, ",")
+		whole = strings.TrimLeft(whole, "+-")
+		if len(fraction) == 3 && whole != "" && strings.Trim(whole, "0") != "" {
+			group = ","
+		} else {
+			decimal = ","
+		}
+	case dots > 1:
+		group = "."
+	}
+
+	if group != "" {
+		mantissa = strings.ReplaceAll(mantissa, group, "")
+	}
+	if decimal == "," {
+		mantissa = strings.Replace(mantissa, ",", ".", 1)
+	}
+	return strconv.ParseFloat(mantissa+exponent, 64)
+}
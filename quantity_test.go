@@ -0,0 +1,138 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuantity(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     string
+		want    Quantity
+		wantErr bool
+	}{
+		"slash compound unit": {
+			arg:  "10 gal/ac",
+			want: Quantity{10, VolumeAreaRatioUnit{Gallon, Acre}, VolumeAreaRatioDimension},
+		},
+		"decimal comma": {
+			arg:  "93,5 l/ha",
+			want: Quantity{93.5, VolumeAreaRatioUnit{Litre, Hectare}, VolumeAreaRatioDimension},
+		},
+		"exponent": {
+			arg:  "1.2e3 kg",
+			want: Quantity{1200, Kilogram, MassDimension},
+		},
+		"no space": {
+			arg:  "5lb",
+			want: Quantity{5, Pound, MassDimension},
+		},
+		"exponent form compound unit": {
+			arg:  "100 kg1ha-1",
+			want: Quantity{100, MassAreaRatioUnit{Kilogram, Hectare}, MassAreaRatioDimension},
+		},
+		"per form compound unit": {
+			arg:  "  2.5   litres per hectare ",
+			want: Quantity{2.5, VolumeAreaRatioUnit{Litre, Hectare}, VolumeAreaRatioDimension},
+		},
+		"thousands separator": {
+			arg:  "1,234.5 kg",
+			want: Quantity{1234.5, Kilogram, MassDimension},
+		},
+		"european thousands separator": {
+			arg:  "1.234,5 kg",
+			want: Quantity{1234.5, Kilogram, MassDimension},
+		},
+		"comma with three digits is a thousands separator": {
+			arg:  "1,234 kg",
+			want: Quantity{1234, Kilogram, MassDimension},
+		},
+		"leading zero with three digits is a decimal": {
+			arg:  "0,125 kg",
+			want: Quantity{0.125, Kilogram, MassDimension},
+		},
+		"negative": {
+			arg:  "-3 ha",
+			want: Quantity{-3, Hectare, AreaDimension},
+		},
+		"ambiguous unit uses UnitFromLabel order": {
+			arg:  "5 m",
+			want: Quantity{5, Metre, LineDimension},
+		},
+		"dilution rate": {
+			arg:  "10 g/l",
+			want: Quantity{10, RatioUnit{Gram, Litre}, DilutionRateDimension},
+		},
+		"no value":     {arg: "kg", wantErr: true},
+		"no unit":      {arg: "10", wantErr: true},
+		"unknown unit": {arg: "10 xx", wantErr: true},
+		"empty":        {arg: "", wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseQuantity(c.arg)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestParseQuantityStrict(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg           string
+		want          Quantity
+		wantErr       bool
+		wantAmbiguous bool
+	}{
+		"slash compound unit": {
+			arg:  "10 l/ac",
+			want: Quantity{10, VolumeAreaRatioUnit{Litre, Acre}, VolumeAreaRatioDimension},
+		},
+		"exponent": {
+			arg:  "1.2e3 kg",
+			want: Quantity{1200, Kilogram, MassDimension},
+		},
+		"decimal comma":         {arg: "93,5 l/ha", wantErr: true},
+		"no space":              {arg: "5lb", wantErr: true},
+		"two spaces":            {arg: "5  lb", wantErr: true},
+		"thousands":             {arg: "1,234.5 kg", wantErr: true},
+		"ambiguous unit":        {arg: "5 m", wantErr: true, wantAmbiguous: true},
+		"ambiguous in compound": {arg: "5 l/m", wantErr: true, wantAmbiguous: true},
+		"US or imperial gallon": {arg: "10 gal/ac", wantErr: true, wantAmbiguous: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseQuantityStrict(c.arg)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			assert.Equal(t, c.wantAmbiguous, errors.Is(err, ErrAmbiguousUnit))
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestQuantity_To(t *testing.T) {
+	t.Parallel()
+
+	q, err := ParseQuantity("10 gal/ac")
+	assert.NoError(t, err)
+	got, err := q.To("l/ha")
+	assert.NoError(t, err)
+	assert.InDelta(t, 93.5396, got.Value, 0.0001)
+	assert.Equal(t, VolumeAreaRatioUnit{Litre, Hectare}, got.Unit)
+	assert.Equal(t, "93.53956228956228 l1ha-1", got.String())
+
+	_, err = q.To("kg/ha")
+	assert.Error(t, err)
+}
@@ -0,0 +1,304 @@
+package convert
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// QUDTUnitNamespace is the namespace of QUDT unit IRIs, eg http://qudt.org/vocab/unit/KiloGM-PER-HA.
+	QUDTUnitNamespace = "http://qudt.org/vocab/unit/"
+	// QUDTQuantityKindNamespace is the namespace of QUDT quantity kind IRIs, eg
+	// http://qudt.org/vocab/quantitykind/MassPerArea.
+	QUDTQuantityKindNamespace = "http://qudt.org/vocab/quantitykind/"
+)
+
+// qudtUnitPrefix is the prefix of QUDT unit IRIs in compact form, eg unit:KiloGM-PER-HA.
+const qudtUnitPrefix = "unit:"
+
+// qudtUnits maps the standard label of each simple unit to the local name of its QUDT unit IRI, ref:
+// https://www.qudt.org/doc/DOC_VOCAB-UNITS.html. The year is YR_Common, which is 365 days. The decalitre, quintal,
+// stone, month, dozen, thousand, acre inch and bale have no QUDT unit.
+var qudtUnits = map[string]string{
+	// area
+	SquareCentimetreStandard.String(): "CentiM2",
+	SquareMetreStandard.String():      "M2",
+	SquareKilometreStandard.String():  "KiloM2",
+	HectareStandard.String():          "HA",
+	SquareInchStandard.String():       "IN2",
+	SquareFootStandard.String():       "FT2",
+	SquareYardStandard.String():       "YD2",
+	SquareMileStandard.String():       "MI2",
+	AcreStandard.String():             "AC",
+	// line
+	MillimetreStandard.String(): "MilliM",
+	CentimetreStandard.String(): "CentiM",
+	MetreStandard.String():      "M",
+	KilometreStandard.String():  "KiloM",
+	InchStandard.String():       "IN",
+	FootStandard.String():       "FT",
+	YardStandard.String():       "YD",
+	MileStandard.String():       "MI",
+	// mass
+	MilligramStandard.String(): "MilliGM",
+	DecigramStandard.String():  "DeciGM",
+	GramStandard.String():      "GM",
+	KilogramStandard.String():  "KiloGM",
+	TonneStandard.String():     "TONNE",
+	OunceMassStandard.String(): "OZ",
+	PoundStandard.String():     "LB",
+	TonStandard.String():       "TON_SHORT",
+
+	HundredweightStandard.String(): "CWT_SHORT",
+	// time
+	SecondStandard.String(): "SEC",
+	MinuteStandard.String(): "MIN",
+	HourStandard.String():   "HR",
+	DayStandard.String():    "DAY",
+	WeekStandard.String():   "WK",
+	YearStandard.String():   "YR_Common",
+	// volume
+	MicrolitreStandard.String():      "MicroL",
+	MillilitreStandard.String():      "MilliL",
+	CentilitreStandard.String():      "CentiL",
+	DecilitreStandard.String():       "DeciL",
+	LitreStandard.String():           "L",
+	KilolitreStandard.String():       "KiloL",
+	HectolitreStandard.String():      "HectoL",
+	MegalitreStandard.String():       "MegaL",
+	CubicCentimetreStandard.String(): "CentiM3",
+	CubicMetreStandard.String():      "M3",
+	GallonStandard.String():          "GAL_US",
+	FluidOunceStandard.String():      "OZ_VOL_US",
+	QuartStandard.String():           "QT_US",
+	PintStandard.String():            "PINT_US",
+	CubicInchStandard.String():       "IN3",
+	CubicFootStandard.String():       "FT3",
+	CubicYardStandard.String():       "YD3",
+	AcreFootStandard.String():        "AC-FT",
+	BushelStandard.String():          "BU_US",
+	// temperature
+	KelvinStandard.String():     "K",
+	CelsiusStandard.String():    "DEG_C",
+	FahrenheitStandard.String(): "DEG_F",
+	// amount
+	MicromoleStandard.String(): "MicroMOL",
+	MillimoleStandard.String(): "MilliMOL",
+	MoleStandard.String():      "MOL",
+	KilomoleStandard.String():  "KiloMOL",
+	// count
+	CountStandard.String(): "NUM",
+	// fraction
+	PercentStandard.String():         "PERCENT",
+	PerMilleStandard.String():        "PERMILLE",
+	PartsPerMillionStandard.String(): "PPM",
+	PartsPerBillionStandard.String(): "PPB",
+}
+
+// qudtUnitsByName maps the local names of QUDT units back to the simple units.
+var qudtUnitsByName = func() map[string]Unit {
+	m := make(map[string]Unit, len(qudtUnits))
+	for _, u := range simpleUnits() {
+		if name, ok := qudtUnits[u.String()]; ok {
+			m[name] = u
+		}
+	}
+	return m
+}()
+
+// qudtQuantityKinds maps dimension vectors to the local names of QUDT quantity kinds, ref:
+// https://www.qudt.org/doc/DOC_VOCAB-QUANTITY-KINDS.html.
+var qudtQuantityKinds = map[DimensionVector]string{
+	{}:                              "DimensionlessRatio",
+	{Length: 1}:                     "Length",
+	{Length: 2}:                     "Area",
+	{Length: 3}:                     "Volume",
+	{Mass: 1}:                       "Mass",
+	{Time: 1}:                       "Time",
+	{Temperature: 1}:                "ThermodynamicTemperature",
+	{Amount: 1}:                     "AmountOfSubstance",
+	{Count: 1}:                      "Count",
+	{Time: -1}:                      "Frequency",
+	{Mass: 1, Length: -2}:           "MassPerArea",
+	{Mass: 1, Length: -3}:           "Density",
+	{Mass: 1, Length: -2, Time: -1}: "MassPerAreaTime",
+	{Mass: 1, Time: -1}:             "MassFlowRate",
+	{Length: 1, Time: -1}:           "Velocity",
+	{Length: 1, Time: -2}:           "Acceleration",
+	{Length: 3, Time: -1}:           "VolumeFlowRate",
+	{Amount: 1, Length: -3}:         "AmountOfSubstanceConcentration",
+	{Temperature: 1, Time: -1}:      "TemperatureRateOfChange",
+}
+
+// QUDTUnitIRI returns the QUDT unit IRI of the unit, eg http://qudt.org/vocab/unit/KiloGM-PER-HA for kg/ha. Compound
+// units are named with the QUDT convention of the numerator units, then PER and the denominator units, separated by
+// '-', eg L-PER-HA or TONNE-PER-HA-YR_Common. It returns an *UnmappedQUDTError if the unit, or a unit in a compound
+// unit, has no QUDT unit, such as the bale or a ScaledUnit.
+func QUDTUnitIRI(u Unit) (string, error) {
+	var num, den []string
+	for _, t := range unitTerms(u) {
+		exp := t.Exponent
+		if exp < 0 {
+			exp = -exp
+		}
+		name, err := qudtTerm(t.Unit, exp)
+		if err != nil {
+			return "", err
+		}
+		if t.Exponent < 0 {
+			den = append(den, name)
+		} else {
+			num = append(num, name)
+		}
+	}
+	name := strings.Join(num, "-")
+	if len(den) > 0 {
+		if name != "" {
+			name += "-"
+		}
+		name += "PER-" + strings.Join(den, "-")
+	}
+	return QUDTUnitNamespace + name, nil
+}
+
+// QUDTUnitIRIFromLabel returns the QUDT unit IRI for the specified unit label, so that StandardLabel output can be
+// exported as linked data.
+func QUDTUnitIRIFromLabel(label string) (string, error) {
+	u, err := UnitFromLabel(label)
+	if err != nil {
+		return "", err
+	}
+	return QUDTUnitIRI(u)
+}
+
+// UnitFromQUDT returns the unit for a QUDT unit IRI, which can be a full IRI, eg
+// http://qudt.org/vocab/unit/KiloGM-PER-HA, a compact IRI, eg unit:KiloGM-PER-HA, or a local name, eg KiloGM-PER-HA.
+// Units that are not simple units, or mass/area, volume/area or dilution rate units, are returned as a
+// CompoundUnit. It returns an *UnmappedQUDTError if the IRI has a unit with no equivalent in the package.
+func UnitFromQUDT(iri string) (Unit, error) {
+	name := strings.TrimPrefix(strings.TrimPrefix(iri, QUDTUnitNamespace), qudtUnitPrefix)
+	var num, den string
+	if strings.HasPrefix(name, "PER-") {
+		den = strings.TrimPrefix(name, "PER-")
+	} else {
+		num, den, _ = strings.Cut(name, "-PER-")
+	}
+	var terms []UnitPower
+	for i, part := range []string{num, den} {
+		if part == "" {
+			continue
+		}
+		xs, err := qudtTerms(part)
+		if err != nil {
+			return nil, &UnmappedQUDTError{IRI: iri}
+		}
+		for _, t := range xs {
+			if i == 1 {
+				t.Exponent = -t.Exponent
+			}
+			terms = append(terms, t)
+		}
+	}
+	if len(terms) == 0 {
+		return nil, &UnmappedQUDTError{IRI: iri}
+	}
+	return unitFromTerms(terms), nil
+}
+
+// QUDTQuantityKind returns the QUDT quantity kind IRI of the unit, eg http://qudt.org/vocab/quantitykind/MassPerArea
+// for kg/ha. Volume/area units are VolumePerArea, and dilution rates of two masses or two volumes are MassRatio and
+// VolumeFraction. It returns an *UnmappedQUDTError if there is no quantity kind for the dimension of the unit.
+func QUDTQuantityKind(u Unit) (string, error) {
+	switch v := u.(type) {
+	case VolumeAreaRatioUnit:
+		return QUDTQuantityKindNamespace + "VolumePerArea", nil
+	case RatioUnit:
+		_, nm := v.Numerator.(MassUnit)
+		_, dm := v.Denominator.(MassUnit)
+		_, nv := v.Numerator.(VolumeUnit)
+		_, dv := v.Denominator.(VolumeUnit)
+		switch {
+		case nm && dm:
+			return QUDTQuantityKindNamespace + "MassRatio", nil
+		case nv && dv:
+			return QUDTQuantityKindNamespace + "VolumeFraction", nil
+		}
+	}
+	d, err := DimensionVectorOf(u)
+	if err != nil {
+		return "", err
+	}
+	kind, ok := qudtQuantityKinds[d]
+	if !ok {
+		return "", &UnmappedQUDTError{Unit: u.String()}
+	}
+	return QUDTQuantityKindNamespace + kind, nil
+}
+
+// isQUDTUnitIRI returns true if the label is a full or compact QUDT unit IRI.
+func isQUDTUnitIRI(label string) bool {
+	return strings.HasPrefix(label, QUDTUnitNamespace) || strings.HasPrefix(label, qudtUnitPrefix)
+}
+
+// qudtTerm returns the QUDT local name of a simple unit raised to the positive power exp, eg M2 or SEC2.
+func qudtTerm(u Unit, exp int) (string, error) {
+	name, ok := qudtUnits[u.String()]
+	if !ok {
+		return "", &UnmappedQUDTError{Unit: u.String()}
+	}
+	if exp == 1 {
+		return name, nil
+	}
+	if strings.Contains(name, "-") {
+		return "", &UnmappedQUDTError{Unit: u.String()}
+	}
+	base, power := splitQUDTExponent(name)
+	return base + strconv.Itoa(power*exp), nil
+}
+
+// qudtTerms reads the units in one side of a QUDT local name, eg KiloGM or HA-YR_Common, matching the longest unit
+// name at each position so that AC-FT is the acre foot. A unit name can be followed by an exponent, eg SEC2.
+func qudtTerms(s string) ([]UnitPower, error) {
+	var terms []UnitPower
+	for s != "" {
+		t, n, ok := matchQUDTTerm(s)
+		if !ok {
+			return nil, &UnmappedQUDTError{IRI: s}
+		}
+		terms = append(terms, t)
+		s = strings.TrimPrefix(s[n:], "-")
+	}
+	return terms, nil
+}
+
+// matchQUDTTerm returns the unit with the longest QUDT local name at the start of s, which must be followed by '-' or
+// the end of s, and the length of the name.
+func matchQUDTTerm(s string) (UnitPower, int, bool) {
+	for n := len(s); n > 0; n-- {
+		if n < len(s) && s[n] != '-' {
+			continue
+		}
+		if u, ok := qudtUnitsByName[s[:n]]; ok {
+			return UnitPower{Unit: u, Exponent: 1}, n, true
+		}
+		if base, power := splitQUDTExponent(s[:n]); power != 1 {
+			if u, ok := qudtUnitsByName[base]; ok {
+				return UnitPower{Unit: u, Exponent: power}, n, true
+			}
+		}
+	}
+	return UnitPower{}, 0, false
+}
+
+// splitQUDTExponent splits a QUDT local name such as M2 or SEC2 into its base and exponent.
+func splitQUDTExponent(name string) (string, int) {
+	base := strings.TrimRight(name, "0123456789")
+	if base == name || base == "" {
+		return name, 1
+	}
+	n, err := strconv.Atoi(name[len(base):])
+	if err != nil {
+		return name, 1
+	}
+	return base, n
+}
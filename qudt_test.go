@@ -0,0 +1,124 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQUDTUnitIRI(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg      string
+		want     string
+		wantKind string
+	}{
+		"mass":            {arg: "kg", want: "KiloGM", wantKind: "Mass"},
+		"mass per area":   {arg: "kg/ha", want: "KiloGM-PER-HA", wantKind: "MassPerArea"},
+		"volume per area": {arg: "l/ha", want: "L-PER-HA", wantKind: "VolumePerArea"},
+		"us rate":         {arg: "gal/ac", want: "GAL_US-PER-AC", wantKind: "VolumePerArea"},
+		"mass ratio":      {arg: "mg/kg", want: "MilliGM-PER-KiloGM", wantKind: "MassRatio"},
+		"volume fraction": {arg: "ml/l", want: "MilliL-PER-L", wantKind: "VolumeFraction"},
+		"density":         {arg: "kg/m3", want: "KiloGM-PER-M3", wantKind: "Density"},
+		"yield per year":  {arg: "t/ha/yr", want: "TONNE-PER-HA-YR_Common", wantKind: "MassPerAreaTime"},
+		"acceleration":    {arg: "m/s2", want: "M-PER-SEC2", wantKind: "Acceleration"},
+		"reciprocal":      {arg: "1/s", want: "PER-SEC", wantKind: "Frequency"},
+		"acre foot":       {arg: "ac-ft", want: "AC-FT", wantKind: "Volume"},
+		"temperature":     {arg: "degC", want: "DEG_C", wantKind: "ThermodynamicTemperature"},
+		"fraction":        {arg: "ppm", want: "PPM", wantKind: "DimensionlessRatio"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := UnitFromLabel(c.arg)
+			assert.NoError(t, err)
+			got, err := QUDTUnitIRI(u)
+			assert.NoError(t, err)
+			assert.Equal(t, QUDTUnitNamespace+c.want, got)
+			kind, err := QUDTQuantityKind(u)
+			assert.NoError(t, err)
+			assert.Equal(t, QUDTQuantityKindNamespace+c.wantKind, kind)
+
+			back, err := UnitFromQUDT(got)
+			assert.NoError(t, err)
+			assert.Equal(t, u.String(), back.String())
+		})
+	}
+}
+
+func TestUnitFromQUDT(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg  string
+		want string
+	}{
+		"full iri":     {arg: "http://qudt.org/vocab/unit/KiloGM-PER-HA", want: "kg1ha-1"},
+		"compact iri":  {arg: "unit:L-PER-HA", want: "l1ha-1"},
+		"local name":   {arg: "LB-PER-AC", want: "lb1ac-1"},
+		"percent":      {arg: "PERCENT", want: "%"},
+		"powered base": {arg: "M-PER-SEC2", want: "m1s-2"},
+		"acre foot":    {arg: "AC-FT-PER-HR", want: "ac-ft1h-1"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnitFromQUDT(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got.String())
+		})
+	}
+}
+
+func TestQUDT_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnitFromQUDT("unit:FURLONG-PER-FORTNIGHT")
+	assert.True(t, errors.Is(err, ErrUnmappedQUDT), err)
+
+	_, err = QUDTUnitIRI(Bale)
+	assert.True(t, errors.Is(err, ErrUnmappedQUDT), err)
+	assert.EqualError(t, err, "unmapped QUDT unit: unit bale has no QUDT equivalent")
+
+	u, err := UnitFromLabel("lb/1000 ft2")
+	assert.NoError(t, err)
+	_, err = QUDTUnitIRI(u)
+	assert.True(t, errors.Is(err, ErrUnmappedQUDT), err)
+
+	_, err = ValueFromTo(1, "unit:FURLONG", "m")
+	assert.True(t, errors.Is(err, ErrUnmappedQUDT), err)
+}
+
+// TestQUDTUnits checks that every simple unit with a QUDT unit is read back from its IRI.
+func TestQUDTUnits(t *testing.T) {
+	t.Parallel()
+
+	for _, u := range simpleUnits() {
+		if _, ok := qudtUnits[u.String()]; !ok {
+			continue
+		}
+		iri, err := QUDTUnitIRI(u)
+		assert.NoError(t, err, u.String())
+		got, err := UnitFromQUDT(iri)
+		assert.NoError(t, err, iri)
+		assert.Equal(t, u.String(), got.String(), iri)
+	}
+}
+
+func TestValueFromTo_QUDT(t *testing.T) {
+	t.Parallel()
+
+	got, err := ValueFromTo(100, "unit:KiloGM-PER-HA", "lb/ac")
+	assert.NoError(t, err)
+	assert.InDelta(t, 89.2179, got, 0.0001)
+
+	iri, err := QUDTUnitIRIFromLabel("litres per hectare")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://qudt.org/vocab/unit/L-PER-HA", iri)
+}
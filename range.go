@@ -0,0 +1,109 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// rangePattern matches label rates such as "1.5-2 pt/A", "up to 40 oz/ac" and "0.5 to 1 L/ha".
+var rangePattern = regexp.MustCompile(`^(?i:(up\s+to)\s+)?(\d+(?:\.\d+)?|\.\d+)(?:\s*(?:-|–|—|(?i:to))\s*(\d+(?:\.\d+)?|\.\d+))?\s*(.+)$`)
+
+// Range is a quantity given as a range of values in a unit, such as a pesticide or fertiliser label rate.
+type Range struct {
+	Min  float64
+	Max  float64
+	Unit string
+}
+
+// NewRange returns a Range with the specified bounds and unit. It returns an error if min is greater than max.
+func NewRange(min, max float64, unit string) (Range, error) {
+	if min > max {
+		return Range{}, fmt.Errorf("range minimum %v is greater than maximum %v", min, max)
+	}
+	return Range{
+		Min:  min,
+		Max:  max,
+		Unit: unit,
+	}, nil
+}
+
+// ParseRange parses a range such as "1.5-2 pt/A", "1.5–2 pt/ac", "0.5 to 1 L/ha" or "up to 40 oz/ac". An "up to"
+// range has a minimum of zero, and a single value such as "2 pt/A" is a range with the same minimum and maximum.
+// The acre abbreviation "A" or "a" is replaced with "ac" in the unit of the returned range.
+func ParseRange(s string) (Range, error) {
+	m := rangePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Range{}, fmt.Errorf("cannot parse range: %s", s)
+	}
+	upTo, lower, upper, unit := m[1] != "", m[2], m[3], labelRateUnit(strings.TrimSpace(m[4]))
+	if upTo && upper != "" {
+		return Range{}, fmt.Errorf("cannot parse range: %s, 'up to' range should have a single value", s)
+	}
+	if _, err := UnitFromLabel(unit); err != nil {
+		return Range{}, fmt.Errorf("cannot parse range: %s: %w", s, err)
+	}
+
+	min, err := strconv.ParseFloat(lower, 64)
+	if err != nil {
+		return Range{}, fmt.Errorf("cannot parse range minimum %s: %w", lower, err)
+	}
+	max := min
+	if upper != "" {
+		max, err = strconv.ParseFloat(upper, 64)
+		if err != nil {
+			return Range{}, fmt.Errorf("cannot parse range maximum %s: %w", upper, err)
+		}
+	}
+	if upTo {
+		min = 0
+	}
+	return NewRange(min, max, unit)
+}
+
+// labelRateUnit replaces the "A" or "a" that product labels use to abbreviate acres, as in "pt/A" or "lb/a", with
+// "ac". The abbreviation is only recognised as a denominator. A lowercase "a" is the UCUM Julian year, which
+// UnitFromLabel does not read but ParseUCUM does, and a label rate is never per year, so it is read as acres too.
+func labelRateUnit(unit string) string {
+	for _, sep := range []string{"/", " per "} {
+		if n, d, ok := strings.Cut(unit, sep); ok && strings.EqualFold(strings.TrimSpace(d), "a") {
+			return n + sep + "ac"
+		}
+	}
+	return unit
+}
+
+// To converts both bounds of the range to the specified unit, using ValueFromTo.
+func (r Range) To(unit string) (Range, error) {
+	min, err := ValueFromTo(r.Min, r.Unit, unit)
+	if err != nil {
+		return Range{}, err
+	}
+	max, err := ValueFromTo(r.Max, r.Unit, unit)
+	if err != nil {
+		return Range{}, err
+	}
+	return NewRange(min, max, unit)
+}
+
+// Contains returns true if value, in the specified unit, is within the range, including the bounds. For example, it
+// can check whether an application of 1.2 L/ha is within a label rate of 1.5-2 pt/A. The value is converted to the
+// unit of the range, and a small tolerance allows for rounding in that conversion.
+func (r Range) Contains(value float64, unit string) (bool, error) {
+	v, err := ValueFromTo(value, unit, r.Unit)
+	if err != nil {
+		return false, fmt.Errorf("cannot compare %s with range in %s: %w", unit, r.Unit, err)
+	}
+	tolerance := 4 * MaxRelativeError * math.Max(math.Abs(r.Min), math.Abs(r.Max))
+	return v >= r.Min-tolerance && v <= r.Max+tolerance, nil
+}
+
+// String returns the range as a string, eg "1.5-2 pt/ac".
+func (r Range) String() string {
+	if r.Min == r.Max {
+		return fmt.Sprintf("%v %s", r.Min, r.Unit)
+	}
+	return fmt.Sprintf("%v-%v %s", r.Min, r.Max, r.Unit)
+}
@@ -0,0 +1,96 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     string
+		want    Range
+		wantErr bool
+	}{
+		"hyphen":             {arg: "1.5-2 pt/A", want: Range{1.5, 2, "pt/ac"}},
+		"hyphen with spaces": {arg: "1.5 - 2 pt per A", want: Range{1.5, 2, "pt per ac"}},
+		"lowercase acre":     {arg: "1.5-2 pt/a", want: Range{1.5, 2, "pt/ac"}},
+		"en dash":            {arg: "1.5–2 pt/ac", want: Range{1.5, 2, "pt/ac"}},
+		"to":                 {arg: "0.5 to 1 L/ha", want: Range{0.5, 1, "L/ha"}},
+		"up to":              {arg: "up to 40 oz/ac", want: Range{0, 40, "oz/ac"}},
+		"Up To":              {arg: "Up To 40 oz/ac", want: Range{0, 40, "oz/ac"}},
+		"single value":       {arg: "2 tons/ac", want: Range{2, 2, "tons/ac"}},
+		"leading decimal":    {arg: ".5-1 kg/ha", want: Range{0.5, 1, "kg/ha"}},
+		"min greater":        {arg: "2-1 pt/A", wantErr: true},
+		"unknown unit":       {arg: "1-2 xx/ac", wantErr: true},
+		"no unit":            {arg: "1-2", wantErr: true},
+		"up to a range":      {arg: "up to 1-2 pt/A", wantErr: true},
+		"no value":           {arg: "pt/A", wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRange(c.arg)
+			assert.Equal(t, c.wantErr, err != nil)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestRange_To(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseRange("1.5-2 pt/A")
+	assert.NoError(t, err)
+	got, err := r.To("l/ha")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.75387, got.Min, 0.0001)
+	assert.InDelta(t, 2.33849, got.Max, 0.0001)
+	assert.Equal(t, "l/ha", got.Unit)
+
+	_, err = r.To("kg/ha")
+	assert.Error(t, err)
+}
+
+func TestRange_Contains(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseRange("1.5-2 pt/A")
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		value   float64
+		unit    string
+		want    bool
+		wantErr bool
+	}{
+		"below":               {value: 1.2, unit: "l/ha", want: false},
+		"within":              {value: 2, unit: "l/ha", want: true},
+		"above":               {value: 2.5, unit: "l/ha", want: false},
+		"lower bound":         {value: 1.5, unit: "pt/ac", want: true},
+		"converted upper":     {value: 2.338489, unit: "l/ha", want: true},
+		"incompatible units":  {value: 1, unit: "kg/ha", wantErr: true},
+		"unknown unit":        {value: 1, unit: "xx", wantErr: true},
+		"upper in other unit": {value: 32, unit: "floz/ac", want: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := r.Contains(c.value, c.unit)
+			assert.Equal(t, c.wantErr, err != nil)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestRange_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "1.5-2 pt/ac", Range{1.5, 2, "pt/ac"}.String())
+	assert.Equal(t, "2 kg/ha", Range{2, 2, "kg/ha"}.String())
+}
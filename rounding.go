@@ -0,0 +1,207 @@
+package convert
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode determines how a value is rounded to the required number of digits.
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds to the nearest digit, and halves away from zero. This is the rounding used by Round.
+	RoundHalfAwayFromZero RoundingMode = iota
+	// RoundHalfEven rounds to the nearest digit, and halves to the nearest even digit (banker's rounding).
+	RoundHalfEven
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity, eg when ordering enough product to cover an area.
+	RoundCeiling
+	// RoundTowardZero truncates the extra digits.
+	RoundTowardZero
+)
+
+// NumberFormat is a rounding and formatting policy for rendering values and measurements.
+// The zero value rounds half away from zero to a whole number.
+type NumberFormat struct {
+	Mode RoundingMode
+	// DecimalPlaces is the number of digits after the decimal point. It is ignored if SignificantFigures is set.
+	DecimalPlaces int
+	// SignificantFigures rounds to this number of significant figures instead of DecimalPlaces, if greater than zero.
+	SignificantFigures int
+	// Locale sets the decimal and thousands separators. If it is empty, or not supported, numbers are formatted with
+	// a '.' decimal separator and no thousands separator.
+	Locale Locale
+}
+
+// Round returns v rounded according to the format.
+func (f NumberFormat) Round(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	n, places := f.round(v)
+	r, _ := strconv.ParseFloat(formatScaledInt(n, places), 64)
+	return r
+}
+
+// Format returns v as a string rounded according to the format. Trailing zeros are kept, so 93.5 formatted to
+// two decimal places is "93.50", or "93,50" in the de locale.
+func (f NumberFormat) Format(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	n, places := f.round(v)
+	s := formatScaledInt(n, places)
+	if f.Locale == "" {
+		return s
+	}
+	sym, err := symbolsFor(f.Locale)
+	if err != nil {
+		return s
+	}
+	return sym.format(s)
+}
+
+// FormatWithUnit returns v rounded according to the format, followed by the unit label, eg "93.50 l/ha".
+func (f NumberFormat) FormatWithUnit(v float64, unit string) string {
+	if unit == "" {
+		return f.Format(v)
+	}
+	return f.Format(v) + " " + unit
+}
+
+// round returns v rounded to an integer number of units in the last place, and the number of decimal places that the
+// integer is scaled by. Rounding works on the shortest decimal representation of v, so 2.675 rounds as written rather
+// than as its binary approximation, 2.67499999...
+func (f NumberFormat) round(v float64) (*big.Int, int) {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	if f.SignificantFigures <= 0 {
+		return roundRat(r, f.DecimalPlaces, f.Mode), f.DecimalPlaces
+	}
+	places := f.SignificantFigures - 1 - decimalExponent(v)
+	n := roundRat(r, places, f.Mode)
+	// Rounding up may carry into a new digit, eg 9.99 to 2 significant figures is 10, not 10.0.
+	if len(new(big.Int).Abs(n).String()) > f.SignificantFigures {
+		places--
+		n = roundRat(r, places, f.Mode)
+	}
+	return n, places
+}
+
+// decimalExponent returns the power of ten of the leading significant digit of v, eg 2 for 123.4 and -3 for 0.00123.
+func decimalExponent(v float64) int {
+	if v == 0 {
+		return 0
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'e', -1, 64)
+	e, _ := strconv.Atoi(s[strings.IndexByte(s, 'e')+1:])
+	return e
+}
+
+// roundRat returns r × 10^places rounded to an integer using the rounding mode.
+func roundRat(r *big.Rat, places int, mode RoundingMode) *big.Int {
+	scaled := new(big.Rat).Set(r)
+	pow := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+	if places >= 0 {
+		scaled.Mul(scaled, pow)
+	} else {
+		scaled.Quo(scaled, pow)
+	}
+
+	num, den := scaled.Num(), scaled.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+	sign := big.NewInt(int64(num.Sign()))
+	// Compare twice the remainder with the denominator to find out if the discarded part is more or less than a half.
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(den)
+
+	switch mode {
+	case RoundFloor:
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case RoundCeiling:
+		if num.Sign() > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	case RoundTowardZero:
+	case RoundHalfEven:
+		if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+			q.Add(q, sign)
+		}
+	default:
+		if cmp >= 0 {
+			q.Add(q, sign)
+		}
+	}
+	return q
+}
+
+// formatScaledInt formats n / 10^places as a decimal string with exactly places digits after the decimal point.
+func formatScaledInt(n *big.Int, places int) string {
+	digits := new(big.Int).Abs(n).String()
+	sign := ""
+	if n.Sign() < 0 {
+		sign = "-"
+	}
+	if places <= 0 {
+		if digits == "0" {
+			return "0"
+		}
+		return sign + digits + strings.Repeat("0", -places)
+	}
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	i := len(digits) - places
+	return sign + digits[:i] + "." + digits[i:]
+}
+
+// abs returns the absolute value of an int.
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// Format returns the area measurement as a string, eg "1.50 ha".
+func (m AreaMeasurement) Format(f NumberFormat) string {
+	return f.FormatWithUnit(m.Value, m.Unit.String())
+}
+
+// Format returns the line measurement as a string, eg "2.54 cm".
+func (m LineMeasurement) Format(f NumberFormat) string {
+	return f.FormatWithUnit(m.Value, m.Unit.String())
+}
+
+// Format returns the mass measurement as a string, eg "1.00 kg".
+func (m MassMeasurement) Format(f NumberFormat) string {
+	return f.FormatWithUnit(m.Value, m.Unit.String())
+}
+
+// Format returns the time measurement as a string, eg "1.5 h".
+func (m TimeMeasurement) Format(f NumberFormat) string {
+	return f.FormatWithUnit(m.Value, m.Unit.String())
+}
+
+// Format returns the volume measurement as a string, eg "93.50 l".
+func (m VolumeMeasurement) Format(f NumberFormat) string {
+	return f.FormatWithUnit(m.Value, m.Unit.String())
+}
+
+// Format returns the mass / area measurement as a string, eg "100.00 kg/ha".
+func (mr MassAreaRatioMeasure) Format(f NumberFormat) string {
+	return f.FormatWithUnit(mr.Value(), mr.MassMeasurement.Unit.String()+"/"+mr.unitArea.String())
+}
+
+// Format returns the volume / area measurement as a string, eg "93.50 l/ha".
+func (vr VolumeAreaRatioMeasurement) Format(f NumberFormat) string {
+	return f.FormatWithUnit(vr.Value(), vr.VolumeMeasurement.Unit.String()+"/"+vr.AreaUnit.String())
+}
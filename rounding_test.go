@@ -0,0 +1,72 @@
+package convert
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberFormat_Format(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		format NumberFormat
+		arg    float64
+		want   string
+	}{
+		"zero value is whole numbers":     {format: NumberFormat{}, arg: 93.5, want: "94"},
+		"trailing zeros are kept":         {format: NumberFormat{DecimalPlaces: 2}, arg: 93.5, want: "93.50"},
+		"half away from zero":             {format: NumberFormat{DecimalPlaces: 2}, arg: 2.675, want: "2.68"},
+		"negative half away from zero":    {format: NumberFormat{DecimalPlaces: 1}, arg: -0.25, want: "-0.3"},
+		"half even rounds down to even":   {format: NumberFormat{Mode: RoundHalfEven, DecimalPlaces: 1}, arg: 0.25, want: "0.2"},
+		"half even rounds up to even":     {format: NumberFormat{Mode: RoundHalfEven, DecimalPlaces: 1}, arg: 0.35, want: "0.4"},
+		"half even above half":            {format: NumberFormat{Mode: RoundHalfEven, DecimalPlaces: 1}, arg: 0.251, want: "0.3"},
+		"half even whole number":          {format: NumberFormat{Mode: RoundHalfEven}, arg: 2.5, want: "2"},
+		"floor":                           {format: NumberFormat{Mode: RoundFloor, DecimalPlaces: 1}, arg: 1.99, want: "1.9"},
+		"floor negative":                  {format: NumberFormat{Mode: RoundFloor, DecimalPlaces: 1}, arg: -1.91, want: "-2.0"},
+		"ceiling":                         {format: NumberFormat{Mode: RoundCeiling}, arg: 12.01, want: "13"},
+		"ceiling negative":                {format: NumberFormat{Mode: RoundCeiling}, arg: -12.99, want: "-12"},
+		"toward zero":                     {format: NumberFormat{Mode: RoundTowardZero, DecimalPlaces: 2}, arg: -3.14159, want: "-3.14"},
+		"small value with leading zeros":  {format: NumberFormat{DecimalPlaces: 4}, arg: 0.00123, want: "0.0012"},
+		"negative rounds to zero":         {format: NumberFormat{DecimalPlaces: 2}, arg: -0.001, want: "0.00"},
+		"significant figures":             {format: NumberFormat{SignificantFigures: 3}, arg: 93.5396, want: "93.5"},
+		"significant figures small value": {format: NumberFormat{SignificantFigures: 2}, arg: 0.0012345, want: "0.0012"},
+		"significant figures large value": {format: NumberFormat{SignificantFigures: 2}, arg: 12345, want: "12000"},
+		"significant figures trailing 0s": {format: NumberFormat{SignificantFigures: 4}, arg: 2.5, want: "2.500"},
+		"significant figures carry":       {format: NumberFormat{SignificantFigures: 2}, arg: 9.99, want: "10"},
+		"significant figures zero":        {format: NumberFormat{SignificantFigures: 3}, arg: 0, want: "0.00"},
+		"significant figures ceiling":     {format: NumberFormat{Mode: RoundCeiling, SignificantFigures: 1}, arg: 4012, want: "5000"},
+		"not a number":                    {format: NumberFormat{DecimalPlaces: 2}, arg: math.NaN(), want: "NaN"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.want, c.format.Format(c.arg))
+		})
+	}
+}
+
+func TestNumberFormat_Round(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 93.54, NumberFormat{DecimalPlaces: 2}.Round(93.5396))
+	assert.Equal(t, 2.68, NumberFormat{DecimalPlaces: 2}.Round(2.675))
+	assert.Equal(t, 0.2, NumberFormat{Mode: RoundHalfEven, DecimalPlaces: 1}.Round(0.25))
+	assert.Equal(t, 1200.0, NumberFormat{SignificantFigures: 2}.Round(1234))
+	assert.True(t, math.IsInf(NumberFormat{}.Round(math.Inf(1)), 1))
+}
+
+func TestMeasurement_Format(t *testing.T) {
+	t.Parallel()
+	f := NumberFormat{DecimalPlaces: 2}
+	assert.Equal(t, "1.50 ha", AreaMeasurement{1.5, Hectare}.Format(f))
+	assert.Equal(t, "2.54 cm", LineMeasurement{2.54, Centimetre}.Format(f))
+	assert.Equal(t, "1.00 kg", MassMeasurement{1, Kilogram}.Format(f))
+	assert.Equal(t, "1.50 h", TimeMeasurement{1.5, Hour}.Format(f))
+	assert.Equal(t, "93.50 l", VolumeMeasurement{93.5, Litre}.Format(f))
+	assert.Equal(t, "100.00 kg/ha", NewMassAreaRatioMeasure(100, Kilogram, Hectare).Format(f))
+	assert.Equal(t, "93.50 l/ha", NewVolumeAreaMeasurement(93.5, Litre, Hectare).Format(f))
+	assert.Equal(t, "93.5 l/ha", NewVolumeAreaMeasurement(93.54, Litre, Hectare).Format(NumberFormat{SignificantFigures: 3}))
+}
@@ -0,0 +1,22 @@
+package convert
+
+import (
+	"math/big"
+)
+
+// ScaledUnit is a simple unit multiplied by a number, such as the 1000 ft2 in lb/1000 ft2, or the 100 l in kg/100 l.
+// It is usually a term of a CompoundUnit.
+type ScaledUnit struct {
+	Scale *big.Rat
+	Unit  Unit
+}
+
+// String returns the scale and the standard label of the unit, eg "1000 ft2", and satisfies the Unit interface.
+func (u ScaledUnit) String() string {
+	return ratString(u.Scale) + " " + u.Unit.String()
+}
+
+// ratString returns r as a decimal string, or as a fraction if it does not terminate.
+func ratString(r *big.Rat) string {
+	return factor{exact: r}.String()
+}
@@ -0,0 +1,82 @@
+package convert
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaledUnit(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg          string
+		wantString   string
+		wantFullWord string
+	}{
+		"turf rate":        {arg: "lb/1000 ft2", wantString: "lb1[1000 ft2]-1", wantFullWord: "pounds per 1000 square feet"},
+		"sq ft":            {arg: "fl oz/1000 sq ft", wantString: "floz1[1000 ft2]-1", wantFullWord: "fluid ounces per 1000 square feet"},
+		"tank mix":         {arg: "kg/100 L", wantString: "kg1[100 l]-1", wantFullWord: "kilograms per 100 litres"},
+		"row length":       {arg: "seeds/100 m of row", wantString: "count1[100 m]-1", wantFullWord: "count per 100 metres"},
+		"scaled numerator": {arg: "1000 seeds/ha", wantString: "[1000 count]1ha-1", wantFullWord: "1000 count per hectare"},
+		"decimal scale":    {arg: "l/0.5 ha", wantString: "l1[0.5 ha]-1", wantFullWord: "litres per 0.5 hectares"},
+		"round trip":       {arg: "lb1[1000 ft2]-1", wantString: "lb1[1000 ft2]-1", wantFullWord: "pounds per 1000 square feet"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := UnitFromLabel(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantString, u.String())
+			l, err := NewUnitLabel(u)
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantFullWord, l.FullWord())
+		})
+	}
+}
+
+func TestValueFromTo_ScaledUnit(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		"turf to field rate":  {value: 1, from: "lb/1000 ft2", to: "kg/ha", want: 48.82427636383}, // 0.45359237 / 0.09290304 × 10
+		"field to turf rate":  {value: 48.82427636383, from: "kg/ha", to: "lb/1000 sq ft", want: 1},
+		"tank mix":            {value: 1, from: "kg/100 L", to: "g/l", want: 10},
+		"tank mix round trip": {value: 1, from: "kg1[100 l]-1", to: "g/l", want: 10},
+		"seeds per row":       {value: 30, from: "seeds/100 ft of row", to: "seeds/m", want: 30 / 30.48},
+		"fuel use":            {value: 8, from: "l/100 km", to: "ml/km", want: 80},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ValueFromTo(c.value, c.from, c.to)
+			assert.NoError(t, err)
+			assert.InDelta(t, c.want, got, 1e-9)
+		})
+	}
+}
+
+func TestParseUnitExpression_ScaleErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"kg/100", "kg/0 ha", "kg/-10 ha", "kg/m1.5"} {
+		_, err := ParseUnitExpression(arg)
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestScaledUnit_DimensionVector(t *testing.T) {
+	t.Parallel()
+	d, err := DimensionVectorOf(ScaledUnit{Scale: big.NewRat(1000, 1), Unit: SquareFoot})
+	assert.NoError(t, err)
+	assert.Equal(t, DimensionVector{Length: 2}, d)
+}
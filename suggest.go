@@ -0,0 +1,218 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// minSuggestionConfidence is the lowest confidence for a label to be suggested.
+	minSuggestionConfidence = 0.5
+
+	// maxSuggestions is the maximum number of suggestions for an unknown label.
+	maxSuggestions = 5
+
+	// pluralConfidence scales the confidence of a match found by removing a plural suffix, so that "kgs" is a strong
+	// but not certain match for "kg".
+	pluralConfidence = 0.95
+)
+
+// Suggestion is a known unit that is close to an unknown unit label.
+type Suggestion struct {
+	// Label is the closest known label, eg "kg/hectare" for "kgs/hectar". It can be passed to UnitFromLabel.
+	Label string
+	Unit  Unit
+	// Confidence is between 0 and 1, where 1 is an exact match.
+	Confidence float64
+}
+
+// UnknownUnitError is returned when a unit label cannot be resolved to a unit. If the label is part of a compound
+// unit, Input is the compound unit and Position is the byte offset of the label in it. Suggestions for the label are
+// only computed when they are first requested.
+type UnknownUnitError struct {
+	Label    string
+	Input    string
+	Position int
+
+	once        sync.Once
+	suggestions []Suggestion
+}
+
+// Error satisfies the error interface.
+func (e *UnknownUnitError) Error() string {
+	msg := fmt.Sprintf("unhandled unit label: %s", e.Label)
+	if e.Input != "" {
+		msg = fmt.Sprintf("%s at position %d in %s", msg, e.Position, e.Input)
+	}
+	if s, ok := e.Best(minSuggestionConfidence); ok {
+		msg = fmt.Sprintf("%s, did you mean %s?", msg, s.Label)
+	}
+	return msg
+}
+
+// Is allows errors.Is(err, ErrUnknownUnit) to match an UnknownUnitError.
+func (e *UnknownUnitError) Is(target error) bool {
+	return target == ErrUnknownUnit
+}
+
+// Suggestions returns the known units closest to the label, most likely first.
+func (e *UnknownUnitError) Suggestions() []Suggestion {
+	e.once.Do(func() {
+		e.suggestions = SuggestUnits(e.Label)
+	})
+	return e.suggestions
+}
+
+// Best returns the most likely suggestion if its confidence is at least threshold and it is more likely than any
+// other suggestion, so that it can be used to correct the label automatically.
+func (e *UnknownUnitError) Best(threshold float64) (Suggestion, bool) {
+	xs := e.Suggestions()
+	if len(xs) == 0 || xs[0].Confidence < threshold {
+		return Suggestion{}, false
+	}
+	if len(xs) > 1 && xs[1].Confidence == xs[0].Confidence {
+		return Suggestion{}, false
+	}
+	return xs[0], true
+}
+
+// SuggestUnits returns up to five known units whose symbols, full names or aliases are close to the label, most
+// likely first. Matching ignores case and full stops, allows for typing errors such as missing, extra, wrong or
+// swapped letters, and for plurals such as "kgs". Compound labels in slash or 'per' form are matched part by part.
+func SuggestUnits(label string) []Suggestion {
+	var xs []Suggestion
+	if n, sep, d, ok := cutCompoundLabel(label); ok {
+		xs = suggestCompoundUnits(n, sep, d)
+	} else {
+		xs = suggestSimpleUnits(label)
+	}
+	if len(xs) > maxSuggestions {
+		xs = xs[:maxSuggestions]
+	}
+	return xs
+}
+
+// suggestSimpleUnits returns the simple units close to the label, most likely first, with the best label of each.
+func suggestSimpleUnits(label string) []Suggestion {
+	s := normaliseSuggestionLabel(label)
+	variants := map[string]float64{s: 1}
+	for _, suffix := range []string{"es", "s"} {
+		if v := strings.TrimSuffix(s, suffix); v != s && v != "" {
+			variants[v] = pluralConfidence
+		}
+	}
+
+	var xs []Suggestion
+	for _, u := range simpleUnits() {
+		best := Suggestion{Unit: u}
+		for _, l := range unitLabels(u) {
+			if l == "" {
+				continue
+			}
+			for v, scale := range variants {
+				c := scale * similarity(v, normaliseSuggestionLabel(l))
+				if c > best.Confidence || (c == best.Confidence && len(l) < len(best.Label)) {
+					best.Label, best.Confidence = l, c
+				}
+			}
+		}
+		if best.Confidence >= minSuggestionConfidence {
+			xs = append(xs, best)
+		}
+	}
+	sortSuggestions(xs)
+	return xs
+}
+
+// suggestCompoundUnits returns the compound units that combine suggestions for the numerator and denominator.
+func suggestCompoundUnits(n, sep, d string) []Suggestion {
+	var xs []Suggestion
+	for _, sn := range suggestSimpleUnits(n) {
+		for _, sd := range suggestSimpleUnits(d) {
+			c := sn.Confidence * sd.Confidence
+			if c < minSuggestionConfidence {
+				continue
+			}
+			label := sn.Label + sep + sd.Label
+			u, err := UnitFromLabel(sn.Unit.String() + "/" + sd.Unit.String())
+			if err != nil {
+				continue
+			}
+			xs = append(xs, Suggestion{Label: label, Unit: u, Confidence: c})
+		}
+	}
+	sortSuggestions(xs)
+	return xs
+}
+
+// cutCompoundLabel splits a label in slash or 'per' form into its numerator, separator and denominator.
+func cutCompoundLabel(label string) (string, string, string, bool) {
+	for _, sep := range []string{"/", " per "} {
+		if n, d, ok := strings.Cut(label, sep); ok {
+			return strings.TrimSpace(n), sep, strings.TrimSpace(d), true
+		}
+	}
+	return "", "", "", false
+}
+
+// sortSuggestions sorts suggestions by confidence, then by label.
+func sortSuggestions(xs []Suggestion) {
+	sort.SliceStable(xs, func(i, j int) bool {
+		if xs[i].Confidence != xs[j].Confidence {
+			return xs[i].Confidence > xs[j].Confidence
+		}
+		return xs[i].Label < xs[j].Label
+	})
+}
+
+// normaliseSuggestionLabel folds case, removes full stops and collapses white space, so that "Lbs." matches "lbs".
+func normaliseSuggestionLabel(s string) string {
+	return labelKey(strings.Join(strings.Fields(strings.ReplaceAll(s, ".", "")), " "))
+}
+
+// similarity returns 1 minus the edit distance between a and b as a proportion of the longer of the two.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	n := len(ra)
+	if len(rb) > n {
+		n = len(rb)
+	}
+	if n == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(n)
+}
+
+// editDistance returns the optimal string alignment distance between a and b, which is the number of insertions,
+// deletions, substitutions and transpositions of adjacent characters needed to change a into b.
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = d[i-1][j-1] + cost
+			if v := d[i-1][j] + 1; v < d[i][j] {
+				d[i][j] = v
+			}
+			if v := d[i][j-1] + 1; v < d[i][j] {
+				d[i][j] = v
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
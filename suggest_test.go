@@ -0,0 +1,140 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitFromLabel_UnknownUnitError(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg       string
+		wantBest  string
+		wantUnit  Unit
+		wantError string
+	}{
+		"plural and typo in compound": {
+			arg:       "kgs/hectar",
+			wantBest:  "kg/hectare",
+			wantUnit:  MassAreaRatioUnit{Kilogram, Hectare},
+			wantError: "unhandled unit label: kgs/hectar, did you mean kg/hectare?",
+		},
+		"plural": {
+			arg:       "kgs",
+			wantBest:  "kg",
+			wantUnit:  Kilogram,
+			wantError: "unhandled unit label: kgs, did you mean kg?",
+		},
+		"transposed letters": {
+			arg:       "kilgoram",
+			wantBest:  "kilogram",
+			wantUnit:  Kilogram,
+			wantError: "unhandled unit label: kilgoram, did you mean kilogram?",
+		},
+		"full stop": {
+			arg:       "Lbs.",
+			wantBest:  "lbs",
+			wantUnit:  Pound,
+			wantError: "unhandled unit label: Lbs., did you mean lbs?",
+		},
+		"per form": {
+			arg:       "gal per acer",
+			wantBest:  "gal per acre",
+			wantUnit:  VolumeAreaRatioUnit{Gallon, Acre},
+			wantError: "unhandled unit label: gal per acer, did you mean gal per acre?",
+		},
+		"no suggestion": {
+			arg:       "xx",
+			wantError: "unhandled unit label: xx",
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := UnitFromLabel(c.arg)
+			var e *UnknownUnitError
+			assert.True(t, errors.As(err, &e))
+			assert.Equal(t, c.arg, e.Label)
+			assert.Equal(t, c.wantError, err.Error())
+			s, ok := e.Best(minSuggestionConfidence)
+			assert.Equal(t, c.wantBest != "", ok)
+			assert.Equal(t, c.wantBest, s.Label)
+			assert.Equal(t, c.wantUnit, s.Unit)
+			if ok {
+				u, err := UnitFromLabel(s.Label)
+				assert.NoError(t, err)
+				assert.Equal(t, c.wantUnit, u)
+			}
+		})
+	}
+}
+
+func TestUnknownUnitError_Best(t *testing.T) {
+	t.Parallel()
+
+	e := &UnknownUnitError{Label: "hectar"}
+	s, ok := e.Best(0.8)
+	assert.True(t, ok)
+	assert.Equal(t, Hectare, s.Unit)
+	assert.InDelta(t, 0.857, s.Confidence, 0.001)
+
+	_, ok = e.Best(0.9)
+	assert.False(t, ok, "confidence is below the threshold")
+
+	// "xt" is equally close to ft, pt, qt and st
+	_, ok = (&UnknownUnitError{Label: "xt"}).Best(0)
+	assert.False(t, ok, "best suggestion is not unique")
+
+	s, ok = (&UnknownUnitError{Label: "sqft"}).Best(0)
+	assert.True(t, ok)
+	assert.Equal(t, SquareFoot, s.Unit)
+}
+
+func TestSuggestUnits(t *testing.T) {
+	t.Parallel()
+
+	got := SuggestUnits("galons")
+	assert.LessOrEqual(t, len(got), maxSuggestions)
+	assert.Equal(t, "gallons", got[0].Label)
+	assert.Equal(t, Gallon, got[0].Unit)
+	assert.InDelta(t, 0.857, got[0].Confidence, 0.001)
+	for i := 1; i < len(got); i++ {
+		assert.GreaterOrEqual(t, got[i-1].Confidence, got[i].Confidence)
+	}
+
+	got = SuggestUnits("ha")
+	assert.Equal(t, Suggestion{Label: "ha", Unit: Hectare, Confidence: 1}, got[0])
+
+	assert.Empty(t, SuggestUnits("xx"))
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		a, b string
+		want int
+	}{
+		"equal":         {a: "acre", b: "acre", want: 0},
+		"insertion":     {a: "galon", b: "gallon", want: 1},
+		"deletion":      {a: "litree", b: "litre", want: 1},
+		"substitution":  {a: "litra", b: "litre", want: 1},
+		"two changes":   {a: "litar", b: "litre", want: 2},
+		"transposition": {a: "acer", b: "acre", want: 1},
+		"empty":         {a: "", b: "kg", want: 2},
+		"unicode":       {a: "m²", b: "m2", want: 1},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.want, editDistance([]rune(c.a), []rune(c.b)))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type Temperature string
+
+const (
+	KelvinStandard     Temperature = "K"
+	CelsiusStandard    Temperature = "degC"
+	FahrenheitStandard Temperature = "degF"
+)
+
+// String returns the string representation of the temperature unit.
+func (t Temperature) String() string {
+	return string(t)
+}
+
+// TemperatureUnit represents a temperature unit. Unlike other units, a temperature scale has an offset as well as a
+// conversion factor, eg 0 °C is 273.15 K.
+type TemperatureUnit struct {
+	unit       Temperature
+	full       string
+	fancy      string
+	aliases    []string
+	conversion factor // size of one degree in kelvin
+	offset     factor // kelvin at zero on the scale
+}
+
+// String returns the string representation of the base temperature unit.
+func (u TemperatureUnit) String() string {
+	return u.unit.String()
+}
+
+// Matches returns true if s matches the temperature unit.
+func (u TemperatureUnit) Matches(s string) bool {
+	// The kelvin symbol is case-sensitive, as k is the prefix kilo.
+	if u.unit == KelvinStandard && s == "k" {
+		return false
+	}
+	if strings.EqualFold(u.String(), s) ||
+		strings.EqualFold(u.fancy, s) ||
+		strings.EqualFold(u.full, s) {
+		return true
+	}
+	for _, alias := range u.aliases {
+		if strings.EqualFold(alias, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// temperatureUnits is a list of all supported temperature units.
+var temperatureUnits = []TemperatureUnit{
+	Kelvin,
+	Celsius,
+	Fahrenheit,
+}
+
+var Kelvin = TemperatureUnit{
+	unit:  KelvinStandard,
+	full:  "kelvin",
+	fancy: string(KelvinStandard),
+	aliases: []string{
+		"kelvins",
+	},
+	conversion: exactFactor("1"),
+	offset:     exactFactor("0"),
+}
+
+var Celsius = TemperatureUnit{
+	unit:  CelsiusStandard,
+	full:  "degree Celsius",
+	fancy: "°C",
+	aliases: []string{
+		"degrees Celsius",
+		"celsius",
+		"deg C",
+		"centigrade",
+	},
+	conversion: exactFactor("1"),
+	offset:     exactFactor("273.15"),
+}
+
+var Fahrenheit = TemperatureUnit{
+	unit:  FahrenheitStandard,
+	full:  "degree Fahrenheit",
+	fancy: "°F",
+	aliases: []string{
+		"degrees Fahrenheit",
+		"fahrenheit",
+		"deg F",
+	},
+	conversion: exactFactor("5/9"),
+	offset:     exactFactor("45967/180"), // 459.67 × 5/9
+}
+
+// temperatureUnitFromString returns the first temperature unit that matches s.
+func temperatureUnitFromString(s string) (TemperatureUnit, error) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(TemperatureUnit); ok {
+			return u, nil
+		}
+	}
+	return TemperatureUnit{}, fmt.Errorf("no temperature unit found for %s", s)
+}
+
+// TemperatureMeasurement represents a temperature measurement.
+type TemperatureMeasurement struct {
+	Value float64
+	Unit  TemperatureUnit
+}
+
+// To converts a temperature measurement to the specified unit, allowing for the offset between the scales, eg
+// 20 °C is 68 °F.
+func (m TemperatureMeasurement) To(unit TemperatureUnit) TemperatureMeasurement {
+	m.Value = convertTemperature(m.Value, m.Unit, unit)
+	m.Unit = unit
+	return m
+}
+
+// UnitAs returns the unit of a temperature measurement in the format f, eg "Cel" in UCUMFormat.
+func (m TemperatureMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(m.Unit, f)
+}
+
+// convertTemperature converts an absolute temperature between scales. The conversion is exact for finite values and
+// rounded once to float64.
+func convertTemperature(v float64, from, to TemperatureUnit) float64 {
+	r := new(big.Rat)
+	if r.SetFloat64(v) == nil {
+		return (v*from.conversion.value + from.offset.value - to.offset.value) / to.conversion.value
+	}
+	f, _ := convertTemperatureExact(r, from, to).Float64()
+	return f
+}
+
+// temperatureScale returns the exact scale and offset that convert a temperature from one scale to another, as
+// value × scale + offset.
+func temperatureScale(from, to TemperatureUnit) (*big.Rat, *big.Rat) {
+	scale := new(big.Rat).Quo(from.conversion.exact, to.conversion.exact)
+	offset := new(big.Rat).Sub(from.offset.exact, to.offset.exact)
+	return scale, offset.Quo(offset, to.conversion.exact)
+}
+
+// convertTemperatureExact converts an absolute temperature between scales with exact arithmetic.
+func convertTemperatureExact(v *big.Rat, from, to TemperatureUnit) *big.Rat {
+	k := new(big.Rat).Mul(v, from.conversion.exact)
+	k.Add(k, from.offset.exact)
+	k.Sub(k, to.offset.exact)
+	return k.Quo(k, to.conversion.exact)
+}
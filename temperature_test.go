@@ -0,0 +1,101 @@
+package convert
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_temperatureUnitFromString(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		argList  []string
+		wantUnit TemperatureUnit
+		wantErr  bool
+	}{
+		"kelvin": {
+			argList:  []string{"K", "kelvin", "Kelvins"},
+			wantUnit: Kelvin,
+			wantErr:  false,
+		},
+		"celsius": {
+			argList:  []string{"degC", "°C", "degree Celsius", "degrees celsius", "Celsius", "deg C"},
+			wantUnit: Celsius,
+			wantErr:  false,
+		},
+		"fahrenheit": {
+			argList:  []string{"degF", "°F", "degree Fahrenheit", "fahrenheit", "deg F"},
+			wantUnit: Fahrenheit,
+			wantErr:  false,
+		},
+		"no match": {
+			argList:  []string{"C", "F", "deg", "k"},
+			wantUnit: TemperatureUnit{},
+			wantErr:  true,
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, arg := range c.argList {
+				gotUnit, err := temperatureUnitFromString(arg)
+				assert.Equal(t, c.wantErr, err != nil)
+				assert.Equal(t, c.wantUnit, gotUnit)
+			}
+		})
+	}
+}
+
+func TestTemperatureMeasurement_To(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg  TemperatureMeasurement
+		to   TemperatureUnit
+		want float64
+	}{
+		"freezing C to F":  {arg: TemperatureMeasurement{0, Celsius}, to: Fahrenheit, want: 32},
+		"boiling C to F":   {arg: TemperatureMeasurement{100, Celsius}, to: Fahrenheit, want: 212},
+		"room C to F":      {arg: TemperatureMeasurement{20, Celsius}, to: Fahrenheit, want: 68},
+		"F to C":           {arg: TemperatureMeasurement{-40, Fahrenheit}, to: Celsius, want: -40},
+		"C to K":           {arg: TemperatureMeasurement{25, Celsius}, to: Kelvin, want: 298.15},
+		"absolute zero F":  {arg: TemperatureMeasurement{0, Kelvin}, to: Fahrenheit, want: -459.67},
+		"same unit":        {arg: TemperatureMeasurement{12.5, Celsius}, to: Celsius, want: 12.5},
+		"K to C":           {arg: TemperatureMeasurement{0, Kelvin}, to: Celsius, want: -273.15},
+		"body temperature": {arg: TemperatureMeasurement{98.6, Fahrenheit}, to: Celsius, want: 37},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := c.arg.To(c.to)
+			assert.InDelta(t, c.want, got.Value, 1e-9)
+			assert.Equal(t, c.to, got.Unit)
+		})
+	}
+}
+
+func TestValueFromToExact_Temperature(t *testing.T) {
+	t.Parallel()
+	got, err := ValueFromToExact(big.NewRat(20, 1), "degC", "degF")
+	assert.NoError(t, err)
+	assert.Equal(t, "68", got.RatString())
+}
+
+func TestUnitFromLabel_KelvinCase(t *testing.T) {
+	t.Parallel()
+
+	u, err := UnitFromLabel("K")
+	assert.NoError(t, err)
+	assert.Equal(t, Kelvin, u)
+
+	_, err = UnitFromLabel("k")
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+	_, err = ValueFromTo(1, "k", "degC")
+	assert.Error(t, err)
+}
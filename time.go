@@ -28,7 +28,7 @@ type TimeUnit struct {
 	full       string
 	fancy      string
 	aliases    []string
-	conversion float64
+	conversion factor
 }
 
 // String returns the string representation of the base time unit.
@@ -71,7 +71,7 @@ var Second = TimeUnit{
 		"sec",
 		"secs",
 	},
-	conversion: 1,
+	conversion: exactFactor("1"),
 }
 
 var Minute = TimeUnit{
@@ -83,7 +83,7 @@ var Minute = TimeUnit{
 		"mins",
 		"m",
 	},
-	conversion: 60,
+	conversion: exactFactor("60"),
 }
 
 var Hour = TimeUnit{
@@ -95,7 +95,7 @@ var Hour = TimeUnit{
 		"hr",
 		"hrs",
 	},
-	conversion: 3600,
+	conversion: exactFactor("3600"),
 }
 
 var Day = TimeUnit{
@@ -105,7 +105,7 @@ var Day = TimeUnit{
 	aliases: []string{
 		"days",
 	},
-	conversion: 86400,
+	conversion: exactFactor("86400"),
 }
 
 var Week = TimeUnit{
@@ -116,7 +116,7 @@ var Week = TimeUnit{
 		"weeks",
 		"wks",
 	},
-	conversion: 604800,
+	conversion: exactFactor("604800"),
 }
 
 var Month = TimeUnit{
@@ -126,7 +126,7 @@ var Month = TimeUnit{
 	aliases: []string{
 		"months",
 	},
-	conversion: 2628000,
+	conversion: exactFactor("2628000"), // 1/12 of a 365 day year
 }
 
 var Year = TimeUnit{
@@ -138,13 +138,13 @@ var Year = TimeUnit{
 		"y",
 		"yrs",
 	},
-	conversion: 31536000,
+	conversion: exactFactor("31536000"), // 365 days
 }
 
 // timeUnitFromString returns the first time unit that matches s.
 func timeUnitFromString(s string) (TimeUnit, error) {
-	for _, u := range timeUnits {
-		if u.Matches(s) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(TimeUnit); ok {
 			return u, nil
 		}
 	}
@@ -160,8 +160,13 @@ type TimeMeasurement struct {
 // To converts a time measurement To the specified unit.
 func (m TimeMeasurement) To(unit TimeUnit) TimeMeasurement {
 	if m.Value != 0 {
-		m.Value = (m.Value * m.Unit.conversion) / unit.conversion
+		m.Value = scaleValue(m.Value, m.Unit.conversion, unit.conversion)
 	}
 	m.Unit = unit
 	return m
 }
+
+// UnitAs returns the unit of a time measurement in the format f, eg "h" in SlashFormat.
+func (m TimeMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(m.Unit, f)
+}
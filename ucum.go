@@ -0,0 +1,388 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ucumCodes maps the standard label of each simple unit to its code in the Unified Code for Units of Measure (UCUM),
+// ref: https://ucum.org/ucum. Units that are not UCUM atoms are written as a product with a numeric factor, eg the
+// quintal is 100.kg, and the 365 day year and 1/12 year month are 365.d and 730.h because the UCUM a and mo are based
+// on the Julian year. The acre is [acr_us], which differs from the international acre by 4 ppm. Counts are written as
+// the UCUM annotation {count}. The bale has no UCUM equivalent.
+var ucumCodes = map[string]string{
+	// area
+	SquareCentimetreStandard.String(): "cm2",
+	SquareMetreStandard.String():      "m2",
+	SquareKilometreStandard.String():  "km2",
+	HectareStandard.String():          "har",
+	SquareInchStandard.String():       "[sin_i]",
+	SquareFootStandard.String():       "[sft_i]",
+	SquareYardStandard.String():       "[syd_i]",
+	SquareMileStandard.String():       "[mi_i]2",
+	AcreStandard.String():             "[acr_us]",
+	// line
+	MillimetreStandard.String(): "mm",
+	CentimetreStandard.String(): "cm",
+	MetreStandard.String():      "m",
+	KilometreStandard.String():  "km",
+	InchStandard.String():       "[in_i]",
+	FootStandard.String():       "[ft_i]",
+	YardStandard.String():       "[yd_i]",
+	MileStandard.String():       "[mi_i]",
+	// mass
+	MilligramStandard.String(): "mg",
+	DecigramStandard.String():  "dg",
+	GramStandard.String():      "g",
+	KilogramStandard.String():  "kg",
+	TonneStandard.String():     "t",
+	OunceMassStandard.String(): "[oz_av]",
+	PoundStandard.String():     "[lb_av]",
+	StoneStandard.String():     "[stone_av]",
+	TonStandard.String():       "[ston_av]",
+	QuintalStandard.String():   "100.kg",
+
+	HundredweightStandard.String(): "[scwt_av]",
+	// time
+	SecondStandard.String(): "s",
+	MinuteStandard.String(): "min",
+	HourStandard.String():   "h",
+	DayStandard.String():    "d",
+	WeekStandard.String():   "wk",
+	MonthStandard.String():  "730.h",
+	YearStandard.String():   "365.d",
+	// volume
+	MicrolitreStandard.String():      "uL",
+	MillilitreStandard.String():      "mL",
+	CentilitreStandard.String():      "cL",
+	DecilitreStandard.String():       "dL",
+	LitreStandard.String():           "L",
+	KilolitreStandard.String():       "kL",
+	DecalitreStandard.String():       "daL",
+	HectolitreStandard.String():      "hL",
+	MegalitreStandard.String():       "ML",
+	CubicCentimetreStandard.String(): "cm3",
+	CubicMetreStandard.String():      "m3",
+	GallonStandard.String():          "[gal_us]",
+	FluidOunceStandard.String():      "[foz_us]",
+	QuartStandard.String():           "[qt_us]",
+	PintStandard.String():            "[pt_us]",
+	CubicInchStandard.String():       "[cin_i]",
+	CubicFootStandard.String():       "[cft_i]",
+	CubicYardStandard.String():       "[cyd_i]",
+	AcreFootStandard.String():        "[acr_us].[ft_i]",
+	AcreInchStandard.String():        "[acr_us].[in_i]",
+	BushelStandard.String():          "[bu_us]",
+	// temperature
+	KelvinStandard.String():     "K",
+	CelsiusStandard.String():    "Cel",
+	FahrenheitStandard.String(): "[degF]",
+	// amount
+	MicromoleStandard.String(): "umol",
+	MillimoleStandard.String(): "mmol",
+	MoleStandard.String():      "mol",
+	KilomoleStandard.String():  "kmol",
+	// count
+	CountStandard.String():    "{count}",
+	DozenStandard.String():    "12.{count}",
+	ThousandStandard.String(): "1000.{count}",
+	// fraction
+	PercentStandard.String():         "%",
+	PerMilleStandard.String():        "[ppth]",
+	PartsPerMillionStandard.String(): "[ppm]",
+	PartsPerBillionStandard.String(): "[ppb]",
+}
+
+// ucumUnits maps the UCUM codes that are a single UCUM unit, such as har or [gal_us], back to the simple units.
+var ucumUnits = func() map[string]Unit {
+	m := make(map[string]Unit, len(ucumCodes))
+	for _, u := range simpleUnits() {
+		if code, ok := ucumCodes[u.String()]; ok && !strings.Contains(code, ".") {
+			m[code] = u
+		}
+	}
+	return m
+}()
+
+// ucumOnlyCodes are UCUM units that are read but never written. The UCUM year and month are the Julian year of 365.25
+// days and 1/12 of it. The hectare is also read as ha, which is common in UCUM-coded data although the UCUM ha is a
+// hectoannum.
+var ucumOnlyCodes = map[string]Unit{
+	"ha":   Hectare,
+	"a":    ScaledUnit{Scale: big.NewRat(1461, 4), Unit: Day},
+	"a_j":  ScaledUnit{Scale: big.NewRat(1461, 4), Unit: Day},
+	"mo":   ScaledUnit{Scale: big.NewRat(487, 16), Unit: Day},
+	"mo_j": ScaledUnit{Scale: big.NewRat(487, 16), Unit: Day},
+}
+
+// ucumMetricUnits are the UCUM metric units that can have a prefix, eg ug or nmol, with the package unit for each.
+var ucumMetricUnits = map[string]Unit{
+	"g":   Gram,
+	"t":   Tonne,
+	"m":   Metre,
+	"ar":  ScaledUnit{Scale: big.NewRat(100, 1), Unit: SquareMetre},
+	"L":   Litre,
+	"l":   Litre,
+	"s":   Second,
+	"mol": Mole,
+}
+
+// ucumPrefixes are the UCUM metric prefixes, with the two letter prefix first.
+var ucumPrefixes = []struct {
+	code   string
+	factor *big.Rat
+}{
+	{"da", big.NewRat(10, 1)},
+	{"T", big.NewRat(1000000000000, 1)},
+	{"G", big.NewRat(1000000000, 1)},
+	{"M", big.NewRat(1000000, 1)},
+	{"k", big.NewRat(1000, 1)},
+	{"h", big.NewRat(100, 1)},
+	{"d", big.NewRat(1, 10)},
+	{"c", big.NewRat(1, 100)},
+	{"m", big.NewRat(1, 1000)},
+	{"u", big.NewRat(1, 1000000)},
+	{"n", big.NewRat(1, 1000000000)},
+	{"p", big.NewRat(1, 1000000000000)},
+}
+
+// ParseUCUM returns the unit for a UCUM expression, such as kg/har, [gal_us]/[acr_us], mg/kg or Cel. UCUM codes are
+// case-sensitive, so ML is megalitres and mL is millilitres, and '.' and '/' are applied from left to right. Metric
+// units can have any UCUM prefix, eg ug/L, and a prefixed unit that is not one of the package units is returned as a
+// ScaledUnit. An annotation on its own, such as {seeds}, is read as a count, and an annotation after a unit, such as
+// kg{dry}, is ignored.
+//
+// It returns an *UnmappedUCUMError for a unit with no equivalent in the package, such as [acr_br], or a
+// *MalformedCompoundUnitError if the expression cannot be parsed.
+func ParseUCUM(code string) (Unit, error) {
+	e, err := parseUCUMExpression(code)
+	if err != nil {
+		return nil, err
+	}
+	return unitFromExpr(e)
+}
+
+// FormatUCUM returns the unit as a UCUM expression, eg kg/har or [gal_us]/[acr_us], that can be read back with
+// ParseUCUM. It returns an *UnmappedUCUMError if the unit has no UCUM code, such as the bale.
+func FormatUCUM(u Unit) (string, error) {
+	// Terms with a negative exponent are each written after a '/', because UCUM applies '.' and '/' from left to right
+	var num, den []string
+	for _, t := range unitTerms(u) {
+		exp := t.Exponent
+		if exp < 0 {
+			exp = -exp
+		}
+		code, err := ucumTerm(t.Unit, exp)
+		if err != nil {
+			return "", err
+		}
+		if t.Exponent < 0 {
+			den = append(den, code)
+		} else {
+			num = append(num, code)
+		}
+	}
+	if len(num)+len(den) > 1 {
+		for i := range num {
+			num[i] = groupUCUMCode(num[i])
+		}
+		for i := range den {
+			den[i] = groupUCUMCode(den[i])
+		}
+	}
+	s := strings.Join(num, ".")
+	if s == "" {
+		s = "1"
+	}
+	for _, d := range den {
+		s += "/" + d
+	}
+	return s, nil
+}
+
+// isUnambiguousUCUM returns true if every unit in the UCUM expression is a code that FormatUCUM writes, such as har,
+// Cel or mL, a code in square brackets, such as [gal_us], an annotation, such as {seeds}, or a prefixed metric unit
+// that is not a label in the unit tables, such as ug. A prefixed unit that is a label, such as pt, or a code that is
+// only read, such as a for the Julian year, is more likely to be a label in another notation.
+func isUnambiguousUCUM(e UnitExpr) bool {
+	switch v := e.(type) {
+	case UnitRefExpr:
+		code := v.Label[strings.LastIndexByte(v.Label, ' ')+1:]
+		_, ok := ucumUnits[code]
+		return ok || strings.ContainsAny(code, "[{") || isPrefixedUCUMCode(code)
+	case PowerExpr:
+		return isUnambiguousUCUM(v.Base)
+	case ProductExpr:
+		for _, f := range v.Factors {
+			if !isUnambiguousUCUM(f) {
+				return false
+			}
+		}
+		return true
+	case QuotientExpr:
+		return isUnambiguousUCUM(v.Numerator) && isUnambiguousUCUM(v.Denominator)
+	case UnityExpr:
+		return true
+	}
+	return false
+}
+
+// isPrefixedUCUMCode returns true if the code is a UCUM metric unit with a prefix, such as ug, and is not a label in
+// the unit tables, so that pt is read as the pint rather than the picotonne.
+func isPrefixedUCUMCode(code string) bool {
+	if _, ok := lookupSimpleUnit(code); ok {
+		return false
+	}
+	for _, p := range ucumPrefixes {
+		if _, ok := ucumMetricUnits[strings.TrimPrefix(code, p.code)]; ok && strings.HasPrefix(code, p.code) {
+			return true
+		}
+	}
+	return false
+}
+
+// ucumUnitFromCode returns the unit for a UCUM code that is a single UCUM unit, which can have a metric prefix.
+func ucumUnitFromCode(code string) (Unit, bool) {
+	if u, ok := ucumUnits[code]; ok {
+		return u, true
+	}
+	if u, ok := ucumOnlyCodes[code]; ok {
+		return u, true
+	}
+	if u, ok := ucumMetricUnits[code]; ok {
+		return u, true
+	}
+	for _, p := range ucumPrefixes {
+		if u, ok := ucumMetricUnits[strings.TrimPrefix(code, p.code)]; ok && strings.HasPrefix(code, p.code) {
+			return prefixedUnit(u, p.factor), true
+		}
+	}
+	return nil, false
+}
+
+// prefixedUnit returns the unit u multiplied by a metric prefix, as a simple unit if there is one with the same
+// factor, eg Mg is the tonne, or otherwise as a ScaledUnit, eg ug is 0.000001 g.
+func prefixedUnit(u Unit, prefix *big.Rat) Unit {
+	scale := new(big.Rat).Set(prefix)
+	if s, ok := u.(ScaledUnit); ok {
+		scale.Mul(scale, s.Scale)
+		u = s.Unit
+	}
+	scaled := ScaledUnit{Scale: scale, Unit: u}
+	d, f, err := unitScale(scaled)
+	if err != nil {
+		return scaled
+	}
+	for _, su := range simpleUnits() {
+		if sd, sf, err := unitScale(su); err == nil && sd == d && sf.Cmp(f) == 0 {
+			return su
+		}
+	}
+	return scaled
+}
+
+// maxUCUMPowerOfTen is the largest magnitude of the exponent of a UCUM power of ten, which is that of the largest SI
+// prefix, quetta.
+const maxUCUMPowerOfTen = 30
+
+// lexUCUMPower reads a UCUM power of ten, eg 10*3 or 10^-6, starting at byte offset i in s. It returns false if there
+// is no power of ten at i, or a *MalformedCompoundUnitError if the exponent is larger in magnitude than
+// maxUCUMPowerOfTen.
+func lexUCUMPower(s string, i int) (token, bool, error) {
+	if !strings.HasPrefix(s[i:], "10*") && !strings.HasPrefix(s[i:], "10^") {
+		return token{}, false, nil
+	}
+	n, err := lexNumber(s, i+3)
+	if err != nil || n.scale == nil || !n.scale.IsInt() || n.text == "-" {
+		return token{}, false, nil
+	}
+	if n.scale.Num().CmpAbs(big.NewInt(maxUCUMPowerOfTen)) > 0 {
+		return token{}, false, &MalformedCompoundUnitError{
+			Label:    s,
+			Position: i + 3,
+			Reason:   fmt.Sprintf("power of ten '%s' is out of range, expecting at most %d", n.text, maxUCUMPowerOfTen),
+		}
+	}
+	scale := ratPow(big.NewRat(10, 1), n.value)
+	t := token{text: s[i : i+3+len(n.text)], scale: scale}
+	if scale.IsInt() && scale.Num().IsInt64() {
+		t.value = int(scale.Num().Int64())
+	}
+	return t, true, nil
+}
+
+// ucumWord returns the UCUM unit at the start of s, up to the next operator, digit or annotation.
+func ucumWord(s string) string {
+	if strings.HasPrefix(s, "[") {
+		if end := strings.IndexByte(s, ']'); end >= 0 {
+			return s[:end+1]
+		}
+	}
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || isDigit(r) || strings.ContainsRune("./()[]{}-+", r)
+	})
+	if end == 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		return s[:size]
+	}
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+// ucumTerm returns the UCUM code of a simple or scaled unit raised to the positive power exp.
+func ucumTerm(u Unit, exp int) (string, error) {
+	var code string
+	switch v := u.(type) {
+	case ScaledUnit:
+		if !v.Scale.IsInt() {
+			return "", fmt.Errorf("unit %s has a scale that is not an integer and has no UCUM code", u)
+		}
+		c, err := ucumTerm(v.Unit, 1)
+		if err != nil {
+			return "", err
+		}
+		code = ratString(v.Scale) + "." + c
+	default:
+		c, ok := ucumCodes[u.String()]
+		if !ok {
+			return "", &UnmappedUCUMError{Unit: u.String()}
+		}
+		code = c
+	}
+	if exp == 1 {
+		return code, nil
+	}
+	if strings.Contains(code, ".") || strings.HasPrefix(code, "{") {
+		return "", fmt.Errorf("unit %s cannot be raised to the power %d in UCUM", u, exp)
+	}
+	base, power := splitUCUMExponent(code)
+	return base + strconv.Itoa(power*exp), nil
+}
+
+// splitUCUMExponent splits a UCUM code such as m2 or [mi_i]2 into its base and exponent.
+func splitUCUMExponent(code string) (string, int) {
+	end := strings.TrimRight(code, "0123456789")
+	if end == code || end == "" {
+		return code, 1
+	}
+	n, err := strconv.Atoi(code[len(end):])
+	if err != nil {
+		return code, 1
+	}
+	return end, n
+}
+
+// groupUCUMCode returns the code in parentheses if it is a product, such as 100.kg, so that it is not split by the
+// left to right order of UCUM operators.
+func groupUCUMCode(code string) string {
+	if strings.Contains(code, ".") {
+		return "(" + code + ")"
+	}
+	return code
+}
@@ -0,0 +1,206 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUCUM(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg  string
+		want string
+	}{
+		"mass per area":    {arg: "kg/har", want: "kg1ha-1"},
+		"common hectare":   {arg: "kg/ha", want: "kg1ha-1"},
+		"volume per area":  {arg: "[gal_us]/[acr_us]", want: "gal1ac-1"},
+		"dilution":         {arg: "mg/kg", want: "mg1kg-1"},
+		"temperature":      {arg: "Cel", want: "degC"},
+		"megalitre":        {arg: "ML", want: "Ml"},
+		"millilitre":       {arg: "mL", want: "ml"},
+		"lower case litre": {arg: "ml", want: "ml"},
+		"prefixed unit":    {arg: "Mg/har", want: "t1ha-1"},
+		"scaled prefix":    {arg: "ug/L", want: "[0.000001 g]1l-1"},
+		"fraction":         {arg: "[ppm]", want: "ppm"},
+		"annotation":       {arg: "{seeds}/[acr_us]", want: "count1ac-1"},
+		"unit annotation":  {arg: "kg{dry}/har", want: "kg1ha-1"},
+		"power":            {arg: "m/s2", want: "m1s-2"},
+		"left to right":    {arg: "kg/m.s", want: "kg1m-1s1"},
+		"leading solidus":  {arg: "/s", want: "s-1"},
+		"factor":           {arg: "[lb_av]/(1000.[sft_i])", want: "lb1[1000 ft2]-1"},
+		"power of ten":     {arg: "10*3.{count}/har", want: "[1000 count]1ha-1"},
+		"julian year":      {arg: "t/har/a", want: "t1ha-1[365.25 d]-1"},
+		"package year":     {arg: "t/har/(365.d)", want: "t1ha-1[365 d]-1"},
+		"acre foot":        {arg: "[acr_us].[ft_i]", want: "ac1ft1"},
+		"quintal factor":   {arg: "100.kg", want: "100 kg"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := ParseUCUM(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, u.String())
+		})
+	}
+}
+
+func TestParseUCUM_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg          string
+		wantErr      error
+		wantCode     string
+		wantPosition int
+	}{
+		"unmapped special unit": {arg: "[acr_br]", wantErr: ErrUnmappedUCUM, wantCode: "[acr_br]", wantPosition: 0},
+		"unmapped denominator":  {arg: "kg/[mesh_i]", wantErr: ErrUnmappedUCUM, wantCode: "[mesh_i]", wantPosition: 3},
+		"package label":         {arg: "lb/ac", wantErr: ErrUnmappedUCUM, wantCode: "lb", wantPosition: 0},
+		"unknown unit":          {arg: "kg/furlong", wantErr: ErrUnmappedUCUM, wantCode: "furlong", wantPosition: 3},
+		"unclosed":              {arg: "kg/(har", wantErr: ErrMalformedCompoundUnit},
+		"large power of ten":    {arg: "10*30000", wantErr: ErrMalformedCompoundUnit},
+		"overflow power of ten": {arg: "10^-99999999999999999999.g", wantErr: ErrMalformedCompoundUnit},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseUCUM(c.arg)
+			assert.True(t, errors.Is(err, c.wantErr), err)
+			var ue *UnmappedUCUMError
+			if errors.As(err, &ue) {
+				assert.Equal(t, c.wantCode, ue.Code)
+				assert.Equal(t, c.wantPosition, ue.Position)
+			}
+		})
+	}
+}
+
+func TestFormatUCUM(t *testing.T) {
+	t.Parallel()
+
+	got, err := FormatUCUM(VolumeAreaRatioUnit{Numerator: Gallon, Denominator: Acre})
+	assert.NoError(t, err)
+	assert.Equal(t, "[gal_us]/[acr_us]", got)
+
+	_, err = FormatUCUM(Bale)
+	assert.True(t, errors.Is(err, ErrUnmappedUCUM))
+	assert.EqualError(t, err, "unmapped UCUM unit: unit bale has no UCUM code")
+}
+
+// TestUCUMCodes checks that every simple unit, other than the bale, has a UCUM code that is read back as the same
+// quantity.
+func TestUCUMCodes(t *testing.T) {
+	t.Parallel()
+
+	for _, u := range simpleUnits() {
+		code, ok := ucumCodes[u.String()]
+		if u.String() == BaleStandard.String() {
+			assert.False(t, ok)
+			continue
+		}
+		if !assert.True(t, ok, u.String()) {
+			continue
+		}
+		got, err := ParseUCUM(code)
+		if !assert.NoError(t, err, code) {
+			continue
+		}
+		r, err := conversionRatio(u, got)
+		assert.NoError(t, err, code)
+		assert.Equal(t, "1", r.RatString(), code)
+	}
+}
+
+// TestFormatUCUM_RoundTrip checks that the UCUM code that FormatUCUM writes for a unit is converted as that unit
+// by ValueFromTo, which reads the case-sensitive codes ML and mL as UCUM does.
+func TestFormatUCUM_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	units := []Unit{
+		VolumeAreaRatioUnit{Numerator: Megalitre, Denominator: Hectare},
+		VolumeAreaRatioUnit{Numerator: Millilitre, Denominator: Hectare},
+		MassAreaRatioUnit{Numerator: Kilogram, Denominator: Acre},
+	}
+	for _, u := range simpleUnits() {
+		if u.String() != BaleStandard.String() {
+			units = append(units, u)
+		}
+	}
+	for _, u := range units {
+		code, err := FormatUCUM(u)
+		if !assert.NoError(t, err, u.String()) {
+			continue
+		}
+		got, err := ValueFromTo(1, code, u.String())
+		assert.NoError(t, err, code)
+		assert.InEpsilon(t, 1, got, 1e-12, "%s as %s", code, u)
+	}
+
+	got, err := ValueFromTo(1, "ML", "l")
+	assert.NoError(t, err)
+	assert.Equal(t, 1e6, got)
+	got, err = ValueFromTo(1, "mL", "l")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.001, got)
+}
+
+func TestValueFromTo_UCUM(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		"volume rate":       {value: 10, from: "[gal_us]/[acr_us]", to: "L/har", want: 93.5396},
+		"mixed with labels": {value: 10, from: "gal/ac", to: "L/har", want: 93.5396},
+		"temperature":       {value: 20, from: "Cel", to: "[degF]", want: 68},
+		"dilution":          {value: 1, from: "mg/kg", to: "[ppm]", want: 1},
+		"prefixed":          {value: 1, from: "ug/g", to: "[ppm]", want: 1},
+		"prefixed volume":   {value: 1000, from: "ug/L", to: "mg/L", want: 1},
+		"power of ten":      {value: 1, from: "10*-6.g/g", to: "[ppm]", want: 1},
+		"seeds":             {value: 30000, from: "{seeds}/[acr_us]", to: "seeds/ha", want: 74131.6144},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ValueFromTo(c.value, c.from, c.to)
+			assert.NoError(t, err)
+			assert.InDelta(t, c.want, got, 0.0001)
+		})
+	}
+}
+
+func TestUnitFromLabel_AmbiguousUCUM(t *testing.T) {
+	t.Parallel()
+
+	// Prefixed units that are package labels and codes that are only read are left to ParseUCUM, because they are
+	// more likely to be labels in another notation, eg pt/a for pints per acre.
+	_, err := UnitFromLabel("10*30000")
+	assert.Error(t, err)
+
+	for _, label := range []string{"pt/a", "a"} {
+		_, err := UnitFromLabel(label)
+		assert.True(t, errors.Is(err, ErrUnknownUnit), "%s: %v", label, err)
+		_, err = ParseUCUM(label)
+		assert.NoError(t, err, label)
+		_, err = UnitFromLabelAs(label, UCUMFormat)
+		assert.NoError(t, err, label)
+	}
+}
+
+func TestValueFromTo_UnmappedUCUM(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValueFromTo(1, "[acr_br]", "ha")
+	assert.True(t, errors.Is(err, ErrUnmappedUCUM), err)
+}
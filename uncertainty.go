@@ -0,0 +1,164 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// UncertainMeasurement is a measured value with its standard uncertainty, ie ±1σ, expressed in the same unit as the
+// value. Unit can be any label accepted by ValueFromTo, or empty for a dimensionless value such as a carbon fraction.
+//
+// Arithmetic propagates uncertainty to first order as described in the GUM (JCGM 100:2008), and assumes that the
+// operands are uncorrelated.
+type UncertainMeasurement struct {
+	Value       float64
+	Uncertainty float64
+	Unit        string
+}
+
+// NewUncertainMeasurement returns an UncertainMeasurement with a standard uncertainty in the same unit as the value.
+func NewUncertainMeasurement(value, uncertainty float64, unit string) UncertainMeasurement {
+	return UncertainMeasurement{
+		Value:       value,
+		Uncertainty: math.Abs(uncertainty),
+		Unit:        unit,
+	}
+}
+
+// NewUncertainMeasurementPercent returns an UncertainMeasurement with a standard uncertainty given as a percentage of
+// the value, eg 10 ±5% kg.
+func NewUncertainMeasurementPercent(value, percent float64, unit string) UncertainMeasurement {
+	return NewUncertainMeasurement(value, value*percent/100, unit)
+}
+
+// RelativeUncertainty returns the uncertainty as a fraction of the value.
+func (m UncertainMeasurement) RelativeUncertainty() float64 {
+	if m.Uncertainty == 0 {
+		return 0
+	}
+	return m.Uncertainty / math.Abs(m.Value)
+}
+
+// To converts the measurement to the specified unit. The uncertainty is a difference, so it is scaled by the ratio of
+// the units without the offset of a temperature scale, and the relative uncertainty is unchanged other than for a
+// temperature.
+func (m UncertainMeasurement) To(unit string) (UncertainMeasurement, error) {
+	v, err := ValueFromTo(m.Value, m.Unit, unit)
+	if err != nil {
+		return UncertainMeasurement{}, err
+	}
+	if m.Unit == unit {
+		return NewUncertainMeasurement(v, m.Uncertainty, unit), nil
+	}
+	from, to, err := conversionUnits(m.Unit, unit)
+	if err != nil {
+		return UncertainMeasurement{}, err
+	}
+	r, err := conversionRatio(from, to)
+	if err != nil {
+		return UncertainMeasurement{}, err
+	}
+	return NewUncertainMeasurement(v, m.Uncertainty*floatValue(r), unit), nil
+}
+
+// Add returns the sum of two measurements in the unit of m. The other measurement is converted to the unit of m first,
+// and the uncertainties are added in quadrature.
+func (m UncertainMeasurement) Add(o UncertainMeasurement) (UncertainMeasurement, error) {
+	c, err := o.To(m.Unit)
+	if err != nil {
+		return UncertainMeasurement{}, fmt.Errorf("cannot add %s to %s: %w", o.Unit, m.Unit, err)
+	}
+	return NewUncertainMeasurement(m.Value+c.Value, math.Hypot(m.Uncertainty, c.Uncertainty), m.Unit), nil
+}
+
+// Subtract returns the difference of two measurements in the unit of m. The uncertainties are added in quadrature.
+func (m UncertainMeasurement) Subtract(o UncertainMeasurement) (UncertainMeasurement, error) {
+	c, err := o.To(m.Unit)
+	if err != nil {
+		return UncertainMeasurement{}, fmt.Errorf("cannot subtract %s from %s: %w", o.Unit, m.Unit, err)
+	}
+	return NewUncertainMeasurement(m.Value-c.Value, math.Hypot(m.Uncertainty, c.Uncertainty), m.Unit), nil
+}
+
+// Multiply returns the product of two measurements. The unit is the standard label of the product of the two units,
+// with the powers of the same unit combined, eg t/ha times ha is t. It returns an error if either unit is not a known
+// unit.
+func (m UncertainMeasurement) Multiply(o UncertainMeasurement) (UncertainMeasurement, error) {
+	unit, err := combineUnits(m.Unit, o.Unit, 1)
+	if err != nil {
+		return UncertainMeasurement{}, fmt.Errorf("cannot multiply %s by %s: %w", m.Unit, o.Unit, err)
+	}
+	u := math.Hypot(o.Value*m.Uncertainty, m.Value*o.Uncertainty)
+	return NewUncertainMeasurement(m.Value*o.Value, u, unit), nil
+}
+
+// Divide returns the quotient of two measurements. The unit is the standard label of the quotient of the two units,
+// with the powers of the same unit combined, eg t divided by ha is t1ha-1. It returns an error if the value of o is
+// zero, or if either unit is not a known unit.
+func (m UncertainMeasurement) Divide(o UncertainMeasurement) (UncertainMeasurement, error) {
+	if o.Value == 0 {
+		return UncertainMeasurement{}, errors.New("cannot divide by a measurement with a zero value")
+	}
+	unit, err := combineUnits(m.Unit, o.Unit, -1)
+	if err != nil {
+		return UncertainMeasurement{}, fmt.Errorf("cannot divide %s by %s: %w", m.Unit, o.Unit, err)
+	}
+	u := math.Hypot(m.Uncertainty/o.Value, m.Value*o.Uncertainty/(o.Value*o.Value))
+	return NewUncertainMeasurement(m.Value/o.Value, u, unit), nil
+}
+
+// Scale returns the measurement multiplied by an exact constant, such as a molar mass ratio.
+func (m UncertainMeasurement) Scale(k float64) UncertainMeasurement {
+	return NewUncertainMeasurement(m.Value*k, m.Uncertainty*k, m.Unit)
+}
+
+// String returns the measurement as a string, eg 93.5 ± 1.2 l/ha.
+func (m UncertainMeasurement) String() string {
+	if m.Unit == "" {
+		return fmt.Sprintf("%v ± %v", m.Value, m.Uncertainty)
+	}
+	return fmt.Sprintf("%v ± %v %s", m.Value, m.Uncertainty, m.Unit)
+}
+
+// combineUnits returns the standard label of the unit a multiplied by the unit b raised to the power exp, which is 1
+// for a product and -1 for a quotient. An empty label is dimensionless, and so is a result in which every unit
+// cancels. Terms with the same unit are combined, so the label is one that UnitFromLabel reads.
+func combineUnits(a, b string, exp int) (string, error) {
+	var terms []UnitPower
+	for i, label := range []string{a, b} {
+		if label == "" {
+			continue
+		}
+		u, err := UnitFromLabel(label)
+		if err != nil {
+			return "", err
+		}
+		for _, t := range unitTerms(u) {
+			if i == 1 {
+				t.Exponent *= exp
+			}
+			terms = addTerm(terms, t)
+		}
+	}
+	if len(terms) == 0 {
+		return "", nil
+	}
+	return unitFromTerms(terms).String(), nil
+}
+
+// addTerm adds the term to terms, adding its exponent to that of a term with the same unit, and removing the term if
+// the exponent is then zero.
+func addTerm(terms []UnitPower, t UnitPower) []UnitPower {
+	for i := range terms {
+		if terms[i].Unit.String() != t.Unit.String() {
+			continue
+		}
+		terms[i].Exponent += t.Exponent
+		if terms[i].Exponent == 0 {
+			return append(terms[:i], terms[i+1:]...)
+		}
+		return terms
+	}
+	return append(terms, t)
+}
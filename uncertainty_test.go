@@ -0,0 +1,166 @@
+package convert
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUncertainMeasurement_To(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     UncertainMeasurement
+		toUnit  string
+		want    UncertainMeasurement
+		wantErr bool
+	}{
+		"kg to g": {
+			arg:    NewUncertainMeasurement(1.5, 0.1, "kg"),
+			toUnit: "g",
+			want:   NewUncertainMeasurement(1500, 100, "g"),
+		},
+		"percent uncertainty gal/ac to l/ha": {
+			arg:    NewUncertainMeasurementPercent(10, 5, "gal/ac"),
+			toUnit: "l/ha",
+			want:   NewUncertainMeasurement(93.5396, 4.67698, "l/ha"),
+		},
+		"negative value keeps a positive uncertainty": {
+			arg:    NewUncertainMeasurement(-2, -0.5, "t/ha"),
+			toUnit: "kg/ha",
+			want:   NewUncertainMeasurement(-2000, 500, "kg/ha"),
+		},
+		"temperature uncertainty has no offset": {
+			arg:    NewUncertainMeasurement(20, 1, "degC"),
+			toUnit: "degF",
+			want:   NewUncertainMeasurement(68, 1.8, "degF"),
+		},
+		"temperature to kelvin": {
+			arg:    NewUncertainMeasurement(68, 1.8, "degF"),
+			toUnit: "K",
+			want:   NewUncertainMeasurement(293.15, 1, "K"),
+		},
+		"incompatible units": {
+			arg:     NewUncertainMeasurement(1, 0.1, "kg"),
+			toUnit:  "l",
+			wantErr: true,
+		},
+	}
+
+	const tolerance = 0.0001
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := c.arg.To(c.toUnit)
+			assert.Equal(t, c.wantErr, err != nil)
+			assert.InDelta(t, c.want.Value, got.Value, tolerance)
+			assert.InDelta(t, c.want.Uncertainty, got.Uncertainty, tolerance)
+			assert.Equal(t, c.want.Unit, got.Unit)
+			if !c.wantErr && !IsTemperatureUnit(c.toUnit) {
+				// The offset of a temperature scale changes the value but not the uncertainty.
+				assert.InDelta(t, c.arg.RelativeUncertainty(), got.RelativeUncertainty(), 1e-12)
+			}
+		})
+	}
+}
+
+func TestUncertainMeasurement_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	const tolerance = 1e-9
+	a := NewUncertainMeasurement(3, 0.3, "t")
+	b := NewUncertainMeasurement(400, 40, "kg")
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.InDelta(t, 3.4, sum.Value, tolerance)
+	assert.InDelta(t, math.Sqrt(0.09+0.0016), sum.Uncertainty, tolerance)
+	assert.Equal(t, "t", sum.Unit)
+
+	diff, err := a.Subtract(b)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.6, diff.Value, tolerance)
+	assert.InDelta(t, sum.Uncertainty, diff.Uncertainty, tolerance)
+
+	_, err = a.Add(NewUncertainMeasurement(1, 0, "l"))
+	assert.Error(t, err)
+
+	// Yield of 5 ±0.25 t/ha over 10 ±0.1 ha, ie 5% and 1% relative uncertainty
+	yield := NewUncertainMeasurementPercent(5, 5, "t/ha")
+	area := NewUncertainMeasurement(10, 0.1, "ha")
+	total, err := yield.Multiply(area)
+	assert.NoError(t, err)
+	assert.InDelta(t, 50, total.Value, tolerance)
+	assert.InDelta(t, math.Hypot(0.05, 0.01), total.RelativeUncertainty(), tolerance)
+	assert.Equal(t, "t", total.Unit)
+
+	// Dimensionless carbon fraction keeps the unit
+	carbon, err := total.Multiply(NewUncertainMeasurement(0.45, 0.0225, ""))
+	assert.NoError(t, err)
+	assert.InDelta(t, 22.5, carbon.Value, tolerance)
+	assert.Equal(t, "t", carbon.Unit)
+
+	rate, err := NewUncertainMeasurement(50, 2, "t").Divide(area)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5, rate.Value, tolerance)
+	assert.InDelta(t, math.Hypot(0.04, 0.01), rate.RelativeUncertainty(), tolerance)
+	assert.Equal(t, "t1ha-1", rate.Unit)
+
+	_, err = rate.Divide(NewUncertainMeasurement(0, 1, "ha"))
+	assert.Error(t, err)
+	_, err = rate.Multiply(NewUncertainMeasurement(1, 0, "furlongs"))
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+
+	scaled := yield.Scale(-2)
+	assert.InDelta(t, -10, scaled.Value, tolerance)
+	assert.InDelta(t, 0.5, scaled.Uncertainty, tolerance)
+}
+
+func TestUncertainMeasurement_MultiplyDivideUnits(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		a, b     string
+		multiply bool
+		want     string
+	}{
+		"rate times area":     {a: "t/ha", b: "ha", multiply: true, want: "t"},
+		"different areas":     {a: "t/ha", b: "ac", multiply: true, want: "t1ha-1ac1"},
+		"squared":             {a: "m", b: "m", multiply: true, want: "m2"},
+		"dimensionless":       {a: "", b: "kg/ha", multiply: true, want: "kg1ha-1"},
+		"mass per area":       {a: "kg", b: "ha", want: "kg1ha-1"},
+		"rate per year":       {a: "t/ha", b: "yr", want: "t1ha-1yr-1"},
+		"same unit":           {a: "kg", b: "kg", want: ""},
+		"inverse":             {a: "", b: "ha", want: "ha-1"},
+		"cancel denominators": {a: "kg/ha", b: "l/ha", want: "kg1l-1"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			a, b := NewUncertainMeasurement(2, 0.1, c.a), NewUncertainMeasurement(4, 0.2, c.b)
+			var got UncertainMeasurement
+			var err error
+			if c.multiply {
+				got, err = a.Multiply(b)
+			} else {
+				got, err = a.Divide(b)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got.Unit)
+			if got.Unit != "" {
+				_, err = UnitFromLabel(got.Unit)
+				assert.NoError(t, err, got.Unit)
+			}
+		})
+	}
+}
+
+func TestUncertainMeasurement_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "93.5 ± 1.2 l/ha", NewUncertainMeasurement(93.5, 1.2, "l/ha").String())
+	assert.Equal(t, "0.45 ± 0.02", NewUncertainMeasurement(0.45, 0.02, "").String())
+}
@@ -9,6 +9,68 @@ type Unit interface {
 	String() string
 }
 
+// Dimension identifies the kind of quantity a unit measures.
+type Dimension string
+
+const (
+	AreaDimension            Dimension = "area"
+	LineDimension            Dimension = "line"
+	MassDimension            Dimension = "mass"
+	TimeDimension            Dimension = "time"
+	VolumeDimension          Dimension = "volume"
+	MassAreaRatioDimension   Dimension = "mass/area"
+	VolumeAreaRatioDimension Dimension = "volume/area"
+	DilutionRateDimension    Dimension = "dilution rate"
+	TemperatureDimension     Dimension = "temperature"
+	AmountDimension          Dimension = "amount"
+	CountDimension           Dimension = "count"
+	DimensionlessDimension   Dimension = "dimensionless"
+)
+
+// String returns the string representation of the dimension.
+func (d Dimension) String() string {
+	return string(d)
+}
+
+// DimensionOf returns the dimension of the unit u, or an empty Dimension if it is not known.
+func DimensionOf(u Unit) Dimension {
+	switch v := u.(type) {
+	case AreaUnit:
+		return AreaDimension
+	case LineUnit:
+		return LineDimension
+	case MassUnit:
+		return MassDimension
+	case TimeUnit:
+		return TimeDimension
+	case VolumeUnit:
+		return VolumeDimension
+	case TemperatureUnit:
+		return TemperatureDimension
+	case AmountUnit:
+		return AmountDimension
+	case CountUnit:
+		return CountDimension
+	case FractionUnit:
+		return DimensionlessDimension
+	case ScaledUnit:
+		return DimensionOf(v.Unit)
+	case MassAreaRatioUnit:
+		return MassAreaRatioDimension
+	case VolumeAreaRatioUnit:
+		return VolumeAreaRatioDimension
+	case RatioUnit:
+		if IsDilutionRateUnit(v.String()) {
+			return DilutionRateDimension
+		}
+	case CompoundUnit:
+		if d, err := DimensionVectorOf(v); err == nil {
+			return Dimension(d.String())
+		}
+	}
+	return ""
+}
+
 // RatioUnit is a unit with a numerator and a denominator
 type RatioUnit struct {
 	Numerator   Unit
@@ -24,32 +86,47 @@ func (u RatioUnit) String() string {
 
 // IsAreaUnit returns true if s is a valid area unit.
 func IsAreaUnit(s string) bool {
-	_, err := areaUnitFromString(s)
-	return err == nil
+	return hasSimpleUnit(s, AreaDimension)
 }
 
 // IsLineUnit returns true if the given string is a valid line unit.
 func IsLineUnit(s string) bool {
-	_, err := lineUnitFromString(s)
-	return err == nil
+	return hasSimpleUnit(s, LineDimension)
 }
 
 // IsMassUnit returns true if s is a valid mass unit.
 func IsMassUnit(s string) bool {
-	_, err := massUnitFromString(s)
-	return err == nil
+	return hasSimpleUnit(s, MassDimension)
 }
 
 // IsVolumeUnit returns true if s is a valid volume unit.
 func IsVolumeUnit(s string) bool {
-	_, err := volumeUnitFromString(s)
-	return err == nil
+	return hasSimpleUnit(s, VolumeDimension)
 }
 
 // IsTimeUnit returns true if s is a valid time unit.
 func IsTimeUnit(s string) bool {
-	_, err := timeUnitFromString(s)
-	return err == nil
+	return hasSimpleUnit(s, TimeDimension)
+}
+
+// IsTemperatureUnit returns true if s is a valid temperature unit.
+func IsTemperatureUnit(s string) bool {
+	return hasSimpleUnit(s, TemperatureDimension)
+}
+
+// IsAmountUnit returns true if s is a valid amount of substance unit.
+func IsAmountUnit(s string) bool {
+	return hasSimpleUnit(s, AmountDimension)
+}
+
+// IsCountUnit returns true if s is a valid count unit.
+func IsCountUnit(s string) bool {
+	return hasSimpleUnit(s, CountDimension)
+}
+
+// IsFractionUnit returns true if s is a valid fraction unit, such as a percentage.
+func IsFractionUnit(s string) bool {
+	return hasSimpleUnit(s, DimensionlessDimension)
 }
 
 // IsMassAreaRatioUnit returns true if the unit arg can be identified as a mass/area, otherwise false.
@@ -99,28 +176,53 @@ func IsDilutionRateWithVolumeNumerator(unit string) bool {
 	return IsVolumeUnit(n) && (IsVolumeUnit(d) || IsMassUnit(d))
 }
 
-// UnitFromLabel returns the standard unit for the given unit string.
+// UnitFromLabel returns the standard unit for the given unit string. Compound labels that are not a mass/area,
+// volume/area or dilution rate, such as kg/m3 or t/ha/yr, are returned as a CompoundUnit. Labels that are not known
+// units are read as UCUM expressions, such as [gal_us]/[acr_us], Cel or ug/L, if every unit in them is a UCUM code
+// that FormatUCUM writes, a code in square brackets, an annotation or a prefixed metric unit that is not a package
+// label, so that a label such as pt/a is not read as picotonnes per Julian year. ParseUCUM reads any UCUM expression.
+// QUDT unit IRIs, such as unit:KiloGM-PER-HA, are read with UnitFromQUDT. ADAPT unit of measure codes, such as prcnt
+// or kg1m-2, are read with ParseADAPT. If the label is not a known unit it returns an *UnknownUnitError, which has
+// suggestions for similar labels. Simple units are found with one lookup in an index of their case-folded labels, and
+// other labels are parsed once and cached, so that UnitFromLabel does not allocate for a label it has seen before.
 func UnitFromLabel(label string) (Unit, error) {
+	if u, ok := lookupSimpleUnit(label); ok {
+		return u, nil
+	}
+	if v, ok := parsedUnits.load(label); ok {
+		return v.(Unit), nil
+	}
+	u, err := parseUnitLabel(label)
+	if err != nil {
+		return nil, err
+	}
+	parsedUnits.store(label, u)
+	return u, nil
+}
+
+// parseUnitLabel returns the unit for a label that is not a simple unit, for UnitFromLabel, which caches the result.
+func parseUnitLabel(label string) (Unit, error) {
 	switch {
-	case IsAreaUnit(label):
-		return areaUnitFromString(label)
-	case IsLineUnit(label):
-		return lineUnitFromString(label)
-	case IsMassUnit(label):
-		return massUnitFromString(label)
-	case IsTimeUnit(label):
-		return timeUnitFromString(label)
-	case IsVolumeUnit(label):
-		return volumeUnitFromString(label)
 	case IsMassAreaRatioUnit(label):
 		return massAreaRatioUnitFromString(label)
 	case IsVolumeAreaRatioUnit(label):
 		return volumeAreaRatioUnitFromString(label)
 	case IsDilutionRateUnit(label):
 		return dilutionRateUnitFromString(label)
-	default:
-		return nil, fmt.Errorf("unhandled unit label: %s", label)
 	}
+	if isQUDTUnitIRI(label) {
+		return UnitFromQUDT(label)
+	}
+	if u, err := parseCompoundUnit(label); err == nil {
+		return u, nil
+	}
+	if e, err := parseUCUMExpression(label); err == nil && isUnambiguousUCUM(e) {
+		return unitFromExpr(e)
+	}
+	if u, err := ParseADAPT(label); err == nil {
+		return u, nil
+	}
+	return nil, &UnknownUnitError{Label: label}
 }
 
 // StandardLabel returns a 'standard' label for the specified unit label
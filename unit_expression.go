@@ -0,0 +1,679 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UnitExpr is a node in the syntax tree of a parsed unit expression, such as kg/ha, kg1ha-1yr-1 or kg·m⁻².
+type UnitExpr interface {
+	// String returns the expression with standard unit labels, eg "kg/(ha·yr)".
+	String() string
+	// Position returns the byte offset of the start of the expression in the input.
+	Position() int
+	// Terms returns the simple units in the expression with their overall exponents, eg kg/(ha·yr) is kg1, ha-1
+	// and yr-1.
+	Terms() []UnitPower
+}
+
+// UnitRefExpr is a simple unit in a unit expression.
+type UnitRefExpr struct {
+	Unit  Unit
+	Label string // the label as written, without square brackets
+	Pos   int
+}
+
+// PowerExpr is a unit expression raised to an integer power, eg m2 in exponent form, m^2 or m².
+type PowerExpr struct {
+	Base     UnitExpr
+	Exponent int
+	Pos      int
+}
+
+// ProductExpr is the product of two or more unit expressions, eg kg·m or kg m-2.
+type ProductExpr struct {
+	Factors []UnitExpr
+}
+
+// QuotientExpr is one unit expression divided by another, eg kg/ha or kg per ha.
+type QuotientExpr struct {
+	Numerator   UnitExpr
+	Denominator UnitExpr
+}
+
+// UnityExpr is the number 1 used as a numerator, as in 1/s.
+type UnityExpr struct {
+	Pos int
+}
+
+// String returns the standard label of the unit, in square brackets if it has an exponent or a space.
+func (e UnitRefExpr) String() string {
+	return wrapUnitWithExponent(e.Unit)
+}
+
+// Position returns the byte offset of the unit label in the input.
+func (e UnitRefExpr) Position() int {
+	return e.Pos
+}
+
+// Terms returns the unit with an exponent of 1.
+func (e UnitRefExpr) Terms() []UnitPower {
+	return []UnitPower{{Unit: e.Unit, Exponent: 1}}
+}
+
+// String returns the base and exponent, eg "m^-2".
+func (e PowerExpr) String() string {
+	if e.Exponent == 1 {
+		return e.Base.String()
+	}
+	return fmt.Sprintf("%s^%d", groupUnitExpr(e.Base, false), e.Exponent)
+}
+
+// Position returns the byte offset of the base in the input.
+func (e PowerExpr) Position() int {
+	return e.Base.Position()
+}
+
+// Terms returns the terms of the base with their exponents multiplied by the exponent.
+func (e PowerExpr) Terms() []UnitPower {
+	xs := e.Base.Terms()
+	for i := range xs {
+		xs[i].Exponent *= e.Exponent
+	}
+	return xs
+}
+
+// String returns the factors joined with '·'.
+func (e ProductExpr) String() string {
+	xs := make([]string, len(e.Factors))
+	for i, f := range e.Factors {
+		xs[i] = groupUnitExpr(f, true)
+	}
+	return strings.Join(xs, "·")
+}
+
+// Position returns the byte offset of the first factor in the input.
+func (e ProductExpr) Position() int {
+	return e.Factors[0].Position()
+}
+
+// Terms returns the terms of every factor.
+func (e ProductExpr) Terms() []UnitPower {
+	var xs []UnitPower
+	for _, f := range e.Factors {
+		xs = append(xs, f.Terms()...)
+	}
+	return xs
+}
+
+// String returns the numerator and denominator separated by '/'.
+func (e QuotientExpr) String() string {
+	return e.Numerator.String() + "/" + groupUnitExpr(e.Denominator, false)
+}
+
+// Position returns the byte offset of the numerator in the input.
+func (e QuotientExpr) Position() int {
+	return e.Numerator.Position()
+}
+
+// Terms returns the terms of the numerator followed by the terms of the denominator with negated exponents.
+func (e QuotientExpr) Terms() []UnitPower {
+	xs := e.Numerator.Terms()
+	for _, t := range e.Denominator.Terms() {
+		xs = append(xs, UnitPower{Unit: t.Unit, Exponent: -t.Exponent})
+	}
+	return xs
+}
+
+// String returns "1".
+func (e UnityExpr) String() string {
+	return "1"
+}
+
+// Position returns the byte offset of the 1 in the input.
+func (e UnityExpr) Position() int {
+	return e.Pos
+}
+
+// Terms returns no terms.
+func (e UnityExpr) Terms() []UnitPower {
+	return nil
+}
+
+// groupUnitExpr returns the string of e, in parentheses if it would otherwise be read differently as an operand.
+// Products are only grouped if inProduct is false.
+func groupUnitExpr(e UnitExpr, inProduct bool) string {
+	switch e.(type) {
+	case QuotientExpr:
+		return "(" + e.String() + ")"
+	case ProductExpr:
+		if !inProduct {
+			return "(" + e.String() + ")"
+		}
+	}
+	return e.String()
+}
+
+// ParseUnitExpression parses a unit expression into a syntax tree. The expression can use any mix of the exponent
+// form, eg kg1ha-1yr-1 or [m3]1[m2]-1, the slash form, eg g/plant/day, and the 'per' form, eg kg per ha. Units can
+// be multiplied with '·', '*' or a space, and raised to an integer power with a trailing exponent, eg m-2, with '^',
+// eg m^-2, or with superscripts, eg m⁻². Parentheses group terms, and square brackets wrap a unit label that would
+// otherwise be read differently, eg [fl oz] or [m3]. A unit can be preceded by a scale, as in lb/1000 ft2 or
+// kg/100 l, which gives a ScaledUnit.
+//
+// Unit labels are matched longest first, so m3s-1 is cubic metres per second. Multiplication binds more tightly than
+// division, so kg/ha·yr is kg/(ha·yr), and divisions are applied from left to right, so t/ha/yr is t/(ha·yr).
+//
+// It returns an *UnknownUnitError, or a *MalformedCompoundUnitError, with the byte position of the problem in the
+// input if the expression cannot be parsed.
+func ParseUnitExpression(s string) (UnitExpr, error) {
+	return parseUnitExpression(s, false)
+}
+
+// parseUCUMExpression parses a UCUM expression, such as kg/har, [gal_us]/[acr_us] or 100.kg, into a syntax tree.
+// Only UCUM units are matched, case-sensitively, '.' is multiplication and, as in UCUM, '.' and '/' are applied from
+// left to right, so kg/m.s is (kg/m)·s.
+func parseUCUMExpression(s string) (UnitExpr, error) {
+	return parseUnitExpression(s, true)
+}
+
+// parseUnitExpression parses a unit expression, with UCUM codes and operators if ucum is true.
+func parseUnitExpression(s string, ucum bool) (UnitExpr, error) {
+	tokens, err := lexUnitExpression(s, ucum)
+	if err != nil {
+		return nil, err
+	}
+	p := unitParser{input: s, tokens: tokens, ucum: ucum}
+	e, err := p.parseQuotient()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorAt(t, fmt.Sprintf("unexpected %s", t.describe()))
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenUnit
+	tokenNumber
+	tokenMul
+	tokenDiv
+	tokenCaret
+	tokenOpen
+	tokenClose
+)
+
+// token is a lexical token in a unit expression.
+type token struct {
+	kind  tokenKind
+	text  string
+	pos   int
+	unit  Unit
+	value int
+	scale *big.Rat // the value of a number that is not a superscript, which can be a decimal
+}
+
+// describe returns the token for use in an error message.
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+// maxUnitExponent is the largest magnitude of an exponent in a unit expression, which is far more than any real unit
+// needs and keeps the exact conversion factors small.
+const maxUnitExponent = 9
+
+// maxUnitLabelLen is the length in bytes of the longest simple unit label.
+var maxUnitLabelLen = func() int {
+	n := 0
+	for _, u := range simpleUnits() {
+		for _, label := range unitLabels(u) {
+			if len(label) > n {
+				n = len(label)
+			}
+		}
+	}
+	return n
+}()
+
+// superscriptDigits maps superscript characters back to the characters they represent.
+var superscriptDigits = func() map[rune]rune {
+	m := make(map[rune]rune, len(superscripts))
+	for r, s := range superscripts {
+		m[s] = r
+	}
+	return m
+}()
+
+// lexUnitExpression splits a unit expression into tokens. Unit labels are matched before numbers, so that labels
+// such as 100l are not read as a number. If ucum is true, only UCUM units are matched, '.' is multiplication and
+// annotations in braces, eg {seeds}, are counts or are ignored after a unit.
+func lexUnitExpression(s string, ucum bool) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+		t := token{pos: i}
+		u, label, isLabel := matchUnitLabel(s[i:], ucum)
+		switch {
+		case isLabel:
+			t.kind, t.text, t.unit = tokenUnit, label, u
+		case ucum && r == '.':
+			t.kind, t.text = tokenMul, "."
+		case ucum && r == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, &MalformedCompoundUnitError{Label: s, Position: i, Reason: "unclosed '{'"}
+			}
+			if n := len(tokens); n > 0 && tokens[n-1].kind == tokenUnit && tokens[n-1].pos+len(tokens[n-1].text) == i {
+				// An annotation after a unit, eg kg{dry}, does not change the unit
+				i += end + 1
+				continue
+			}
+			t.kind, t.text, t.unit = tokenUnit, s[i:i+end+1], Each
+		case r == '/':
+			t.kind, t.text = tokenDiv, "/"
+		case r == '*' || r == '·' || r == '⋅' || r == '×':
+			t.kind, t.text = tokenMul, string(r)
+		case r == '^':
+			t.kind, t.text = tokenCaret, "^"
+		case r == '(':
+			t.kind, t.text = tokenOpen, "("
+		case r == ')':
+			t.kind, t.text = tokenClose, ")"
+		case r == '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, &MalformedCompoundUnitError{Label: s, Position: i, Reason: "unclosed '['"}
+			}
+			if ucum {
+				u, ok := ucumUnitFromCode(s[i : i+end+1])
+				if !ok {
+					return nil, &UnmappedUCUMError{Code: s[i : i+end+1], Input: s, Position: i}
+				}
+				tokens = append(tokens, token{kind: tokenUnit, text: s[i : i+end+1], pos: i, unit: u})
+				i += end + 1
+				continue
+			}
+			t.kind, t.text, t.pos = tokenUnit, s[i+1:i+end], i+1
+			u, err := bracketedUnit(strings.TrimSpace(t.text))
+			if err != nil {
+				return nil, &UnknownUnitError{Label: strings.TrimSpace(t.text), Input: s, Position: i + 1}
+			}
+			t.unit = u
+			tokens = append(tokens, t)
+			i += end + 1
+			continue
+		case r == '-' || isDigit(r):
+			if ucum {
+				n, ok, err := lexUCUMPower(s, i)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					t.kind, t.text, t.value, t.scale = tokenNumber, n.text, n.value, n.scale
+					break
+				}
+			}
+			n, err := lexNumber(s, i)
+			if err != nil {
+				return nil, err
+			}
+			t.kind, t.text, t.value, t.scale = tokenNumber, n.text, n.value, n.scale
+		case superscriptDigits[r] != 0:
+			n, err := lexSuperscript(s, i)
+			if err != nil {
+				return nil, err
+			}
+			t.kind, t.text, t.value = tokenNumber, n.text, n.value
+		case ucum:
+			return nil, &UnmappedUCUMError{Code: ucumWord(s[i:]), Input: s, Position: i}
+		default:
+			if !isPerWord(s, i) {
+				return nil, &UnknownUnitError{Label: unknownWord(s[i:]), Input: s, Position: i}
+			}
+			t.kind, t.text = tokenDiv, s[i:i+3]
+		}
+		tokens = append(tokens, t)
+		i += len(t.text)
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(s)}), nil
+}
+
+// lexSuperscript reads an integer in superscript characters, eg ⁻², starting at byte offset i in s.
+func lexSuperscript(s string, i int) (token, error) {
+	var b strings.Builder
+	end := i
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		d, ok := superscriptDigits[r]
+		if !ok || (d == '-' && end > i) {
+			break
+		}
+		b.WriteRune(d)
+		end += size
+	}
+	n, err := strconv.Atoi(b.String())
+	if err != nil {
+		return token{}, &MalformedCompoundUnitError{Label: s, Position: i, Reason: fmt.Sprintf("invalid number '%s'", s[i:end])}
+	}
+	return token{text: s[i:end], value: n}, nil
+}
+
+// lexNumber reads a number starting at byte offset i in s, which is an integer exponent, eg -2, or a scale, eg 1000
+// or 0.5.
+func lexNumber(s string, i int) (token, error) {
+	end := i
+	if end < len(s) && s[end] == '-' {
+		end++
+	}
+	for end < len(s) && isDigit(rune(s[end])) {
+		end++
+	}
+	if end+1 < len(s) && s[end] == '.' && isDigit(rune(s[end+1])) {
+		end++
+		for end < len(s) && isDigit(rune(s[end])) {
+			end++
+		}
+	}
+	r, ok := new(big.Rat).SetString(s[i:end])
+	if !ok {
+		return token{}, &MalformedCompoundUnitError{Label: s, Position: i, Reason: fmt.Sprintf("invalid number '%s'", s[i:end])}
+	}
+	t := token{text: s[i:end], scale: r}
+	if r.IsInt() && r.Num().IsInt64() {
+		t.value = int(r.Num().Int64())
+	}
+	return t, nil
+}
+
+// bracketedUnit returns the simple or scaled unit for a label in square brackets, eg [m3] or [1000 ft2].
+func bracketedUnit(label string) (Unit, error) {
+	if u, ok := lookupSimpleUnit(label); ok {
+		return u, nil
+	}
+	e, err := ParseUnitExpression(label)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := e.(UnitRefExpr); ok {
+		return r.Unit, nil
+	}
+	return nil, fmt.Errorf("%s is not a simple or scaled unit", label)
+}
+
+// isDigit returns true if r is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// matchUnitLabel returns the simple unit with the longest label at the start of s, or the unit with the longest UCUM
+// code if ucum is true. A label that ends with a letter must not be followed by another letter, so that 'm' is not matched at
+// the start of 'ms'.
+func matchUnitLabel(s string, ucum bool) (Unit, string, bool) {
+	n := maxUnitLabelLen
+	if len(s) < n {
+		n = len(s)
+	}
+	for ; n > 0; n-- {
+		if n < len(s) && !utf8.RuneStart(s[n]) {
+			continue
+		}
+		last, _ := utf8.DecodeLastRuneInString(s[:n])
+		next, _ := utf8.DecodeRuneInString(s[n:])
+		if n < len(s) && unicode.IsLetter(last) && unicode.IsLetter(next) {
+			continue
+		}
+		if ucum {
+			if u, ok := ucumUnitFromCode(s[:n]); ok {
+				return u, s[:n], true
+			}
+			continue
+		}
+		if u, ok := lookupSimpleUnit(s[:n]); ok {
+			return u, s[:n], true
+		}
+	}
+	return nil, "", false
+}
+
+// isPerWord returns true if the word at byte offset i in s is 'per', separated from the rest of s by white space.
+func isPerWord(s string, i int) bool {
+	if len(s) < i+3 || !strings.EqualFold(s[i:i+3], "per") {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i+3:])
+	return (i == 0 || unicode.IsSpace(before)) && (i+3 == len(s) || unicode.IsSpace(after))
+}
+
+// unknownWord returns the word at the start of s, up to the next operator, digit or white space.
+func unknownWord(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || isDigit(r) || strings.ContainsRune("/*·⋅×^()[]-", r) ||
+			superscriptDigits[r] != 0
+	})
+	if end == 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		return s[:size]
+	}
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+// unitParser is a recursive descent parser for the grammar:
+//
+//	quotient = product { ( '/' | 'per' ) product }
+//	product  = power { [ '*' | '·' ] power }
+//	power    = primary [ exponent | '^' exponent ]
+//	primary  = [ number ] unit | '(' quotient ')' | '1'
+//
+// UCUM expressions, where '.' and '/' have the same precedence, have the grammar:
+//
+//	term     = [ '/' ] power { ( '.' | '/' ) power }
+//	primary  = [ number [ '.' ] ] unit | '(' term ')' | '1'
+type unitParser struct {
+	input  string
+	tokens []token
+	i      int
+	ucum   bool
+}
+
+// peek returns the next token without consuming it.
+func (p *unitParser) peek() token {
+	return p.tokens[p.i]
+}
+
+// next consumes and returns the next token.
+func (p *unitParser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+// errorAt returns a *MalformedCompoundUnitError at the position of the token.
+func (p *unitParser) errorAt(t token, reason string) error {
+	return &MalformedCompoundUnitError{Label: p.input, Position: t.pos, Reason: reason}
+}
+
+func (p *unitParser) parseQuotient() (UnitExpr, error) {
+	if p.ucum {
+		return p.parseUCUMTerm()
+	}
+	e, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenDiv {
+		p.next()
+		d, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		e = QuotientExpr{Numerator: e, Denominator: d}
+	}
+	return e, nil
+}
+
+func (p *unitParser) parseProduct() (UnitExpr, error) {
+	f, err := p.parsePower()
+	if err != nil {
+		return nil, err
+	}
+	factors := []UnitExpr{f}
+	for {
+		switch p.peek().kind {
+		case tokenMul:
+			p.next()
+		case tokenUnit, tokenOpen:
+		default:
+			if len(factors) == 1 {
+				return f, nil
+			}
+			return ProductExpr{Factors: factors}, nil
+		}
+		f, err := p.parsePower()
+		if err != nil {
+			return nil, err
+		}
+		factors = append(factors, f)
+	}
+}
+
+func (p *unitParser) parseUCUMTerm() (UnitExpr, error) {
+	var e UnitExpr
+	if t := p.peek(); t.kind == tokenDiv {
+		// A leading '/' is the reciprocal, as in /s
+		e = UnityExpr{Pos: t.pos}
+	} else {
+		b, err := p.parsePower()
+		if err != nil {
+			return nil, err
+		}
+		e = b
+	}
+	for {
+		op := p.peek()
+		if op.kind != tokenMul && op.kind != tokenDiv {
+			return e, nil
+		}
+		p.next()
+		f, err := p.parsePower()
+		if err != nil {
+			return nil, err
+		}
+		switch pe, ok := e.(ProductExpr); {
+		case op.kind == tokenDiv:
+			e = QuotientExpr{Numerator: e, Denominator: f}
+		case ok:
+			e = ProductExpr{Factors: append(pe.Factors, f)}
+		default:
+			e = ProductExpr{Factors: []UnitExpr{e, f}}
+		}
+	}
+}
+
+func (p *unitParser) parsePower() (UnitExpr, error) {
+	b, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokenNumber:
+		p.next()
+		if t.scale != nil && !t.scale.IsInt() {
+			return nil, p.errorAt(t, fmt.Sprintf("exponent '%s' is not an integer", t.text))
+		}
+		return p.power(b, t)
+	case t.kind == tokenCaret:
+		p.next()
+		n := p.next()
+		if n.kind != tokenNumber || (n.scale != nil && !n.scale.IsInt()) {
+			return nil, p.errorAt(n, fmt.Sprintf("expected an integer exponent after '^', found %s", n.describe()))
+		}
+		return p.power(b, n)
+	}
+	return b, nil
+}
+
+// power returns the base raised to the integer exponent in the token t. The exponent must not be zero, and neither it
+// nor the exponent of any term of the result can be larger in magnitude than maxUnitExponent.
+func (p *unitParser) power(b UnitExpr, t token) (UnitExpr, error) {
+	if (t.scale != nil && t.scale.Num().CmpAbs(big.NewInt(maxUnitExponent)) > 0) || abs(t.value) > maxUnitExponent {
+		return nil, p.errorAt(t, fmt.Sprintf("exponent '%s' is out of range, expecting at most %d", t.text, maxUnitExponent))
+	}
+	if t.value == 0 {
+		return nil, p.errorAt(t, fmt.Sprintf("exponent '%s' must not be zero", t.text))
+	}
+	for _, x := range b.Terms() {
+		if abs(x.Exponent*t.value) > maxUnitExponent {
+			return nil, p.errorAt(t, fmt.Sprintf("exponent '%s' gives %s an exponent of %d, expecting at most %d",
+				t.text, x.Unit, x.Exponent*t.value, maxUnitExponent))
+		}
+	}
+	return PowerExpr{Base: b, Exponent: t.value, Pos: t.pos}, nil
+}
+
+func (p *unitParser) parsePrimary() (UnitExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenUnit:
+		return UnitRefExpr{Unit: t.unit, Label: t.text, Pos: t.pos}, nil
+	case tokenOpen:
+		e, err := p.parseQuotient()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokenClose {
+			return nil, p.errorAt(c, fmt.Sprintf("expected ')', found %s", c.describe()))
+		}
+		return e, nil
+	case tokenNumber:
+		if u := p.peek(); u.kind == tokenUnit && t.scale != nil {
+			return p.parseScaledUnit(t)
+		}
+		if p.ucum && p.peek().kind == tokenMul && p.tokens[p.i+1].kind == tokenUnit && t.scale != nil {
+			// UCUM writes a factor as a product, eg 100.kg
+			p.next()
+			return p.parseScaledUnit(t)
+		}
+		if t.value == 1 {
+			return UnityExpr{Pos: t.pos}, nil
+		}
+	}
+	return nil, p.errorAt(t, fmt.Sprintf("expected a unit, found %s", t.describe()))
+}
+
+// parseScaledUnit parses a unit with a numeric scale, such as 1000 ft2 or 100 l, where n is the scale.
+func (p *unitParser) parseScaledUnit(n token) (UnitExpr, error) {
+	u := p.next()
+	if n.scale.Sign() <= 0 {
+		return nil, p.errorAt(n, fmt.Sprintf("scale '%s' must be greater than zero", n.text))
+	}
+	if n.scale.Cmp(big.NewRat(1, 1)) == 0 {
+		return UnitRefExpr{Unit: u.unit, Label: u.text, Pos: u.pos}, nil
+	}
+	return UnitRefExpr{
+		Unit:  ScaledUnit{Scale: n.scale, Unit: u.unit},
+		Label: n.text + " " + u.text,
+		Pos:   n.pos,
+	}, nil
+}
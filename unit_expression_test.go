@@ -0,0 +1,106 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnitExpression(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg       string
+		want      string
+		wantTerms string
+	}{
+		"simple unit":           {arg: "kg", want: "kg", wantTerms: "kg1"},
+		"slash form":            {arg: "kg/ha", want: "kg/ha", wantTerms: "kg1ha-1"},
+		"exponent form":         {arg: "kg1ha-1", want: "kg·ha^-1", wantTerms: "kg1ha-1"},
+		"three term exponent":   {arg: "kg1ha-1yr-1", want: "kg·ha^-1·yr^-1", wantTerms: "kg1ha-1yr-1"},
+		"bracketed":             {arg: "[m3]1[m2]-1", want: "[m3]·[m2]^-1", wantTerms: "[m3]1[m2]-1"},
+		"bracketed with space":  {arg: "[fl oz]1ac-1", want: "floz·ac^-1", wantTerms: "floz1ac-1"},
+		"longest label":         {arg: "m3s-1", want: "[m3]·s^-1", wantTerms: "[m3]1s-1"},
+		"space product":         {arg: "kg m-2", want: "kg·m^-2", wantTerms: "kg1m-2"},
+		"three term slash":      {arg: "g/plant/day", want: "g/count/d", wantTerms: "g1count-1d-1"},
+		"per form":              {arg: "kg per ha per yr", want: "kg/ha/yr", wantTerms: "kg1ha-1yr-1"},
+		"unicode":               {arg: "kg·ha⁻¹", want: "kg·ha^-1", wantTerms: "kg1ha-1"},
+		"superscript square":    {arg: "kg/m²", want: "kg/[m2]", wantTerms: "kg1[m2]-1"},
+		"superscript exponent":  {arg: "kg·s⁻²", want: "kg·s^-2", wantTerms: "kg1s-2"},
+		"caret":                 {arg: "m^-2", want: "m^-2", wantTerms: "m-2"},
+		"product binds tighter": {arg: "kg/ha·yr", want: "kg/(ha·yr)", wantTerms: "kg1ha-1yr-1"},
+		"parentheses":           {arg: "kg/(ha yr)", want: "kg/(ha·yr)", wantTerms: "kg1ha-1yr-1"},
+		"grouped power":         {arg: "(m/s)^2", want: "(m/s)^2", wantTerms: "m2s-2"},
+		"unity":                 {arg: "1/s", want: "1/s", wantTerms: "s-1"},
+		"label with digits":     {arg: "g/100l", want: "g/hl", wantTerms: "g1hl-1"},
+		"label with hyphen":     {arg: "ac-ft/yr", want: "ac-ft/yr", wantTerms: "ac-ft1yr-1"},
+		"spaced exponents":      {arg: "kg 1 ha -1", want: "kg·ha^-1", wantTerms: "kg1ha-1"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseUnitExpression(c.arg)
+			assert.NoError(t, err)
+			if err == nil {
+				assert.Equal(t, c.want, got.String())
+				assert.Equal(t, c.wantTerms, CompoundUnit{Terms: got.Terms()}.String())
+			}
+		})
+	}
+}
+
+func TestParseUnitExpression_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg          string
+		wantSentinel error
+		wantLabel    string
+		wantPosition int
+	}{
+		"empty":                {arg: "", wantSentinel: ErrMalformedCompoundUnit},
+		"unknown unit":         {arg: "kg/xx", wantSentinel: ErrUnknownUnit, wantLabel: "xx", wantPosition: 3},
+		"unknown first word":   {arg: "kgha", wantSentinel: ErrUnknownUnit, wantLabel: "kgha"},
+		"unknown bracketed":    {arg: "kg1[ha3]-1", wantSentinel: ErrUnknownUnit, wantLabel: "ha3", wantPosition: 4},
+		"unknown per form":     {arg: "kg per xx yr", wantSentinel: ErrUnknownUnit, wantLabel: "xx", wantPosition: 7},
+		"missing term":         {arg: "t//yr", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 2},
+		"trailing slash":       {arg: "kg/", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 3},
+		"unclosed bracket":     {arg: "kg1[m3-1", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 3},
+		"unclosed paren":       {arg: "kg/(ha yr", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 9},
+		"unopened paren":       {arg: "kg/ha)", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 5},
+		"missing exponent":     {arg: "m^", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 2},
+		"lone minus":           {arg: "m-", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 1},
+		"number as unit":       {arg: "kg/2", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 3},
+		"zero exponent":        {arg: "m^0", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 2},
+		"zero exponent form":   {arg: "ft3000", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 3},
+		"large exponent":       {arg: "ft9999", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 2},
+		"overflow exponent":    {arg: "m99999999999999999999", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 1},
+		"overflow negative":    {arg: "m-99999999999999999999", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 1},
+		"overflow superscript": {arg: "m⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 1},
+		"large superscript":    {arg: "m¹⁰", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 1},
+		"large nested power":   {arg: "(m^5)^2", wantSentinel: ErrMalformedCompoundUnit, wantPosition: 6},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseUnitExpression(c.arg)
+			assert.True(t, errors.Is(err, c.wantSentinel), err)
+			var ue *UnknownUnitError
+			if errors.As(err, &ue) {
+				assert.Equal(t, c.wantLabel, ue.Label)
+				assert.Equal(t, c.arg, ue.Input)
+				assert.Equal(t, c.wantPosition, ue.Position)
+			}
+			var me *MalformedCompoundUnitError
+			if errors.As(err, &me) {
+				assert.Equal(t, c.arg, me.Label)
+				assert.Equal(t, c.wantPosition, me.Position)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnitFormat is the style used to write a unit label. Every format can be read back with UnitFromLabelAs.
+type UnitFormat int
+
+const (
+	// ExponentFormat writes the standard label, with compound units in exponent form, eg kg1ha-1 or [m3]1ha-1.
+	ExponentFormat UnitFormat = iota
+	// SlashFormat writes compound units with '/', eg kg/ha, m3/ha or t/ha/yr.
+	SlashFormat
+	// PerFormat writes compound units with 'per', eg kg per ha.
+	PerFormat
+	// UnicodeFormat writes exponents as superscripts, eg kg ha⁻¹ or m³.
+	UnicodeFormat
+	// UCUMFormat writes the Unified Code for Units of Measure code, eg kg/har or [gal_us]/[acr_us].
+	UCUMFormat
+)
+
+// String returns the name of the format.
+func (f UnitFormat) String() string {
+	switch f {
+	case ExponentFormat:
+		return "exponent"
+	case SlashFormat:
+		return "slash"
+	case PerFormat:
+		return "per"
+	case UnicodeFormat:
+		return "unicode"
+	case UCUMFormat:
+		return "ucum"
+	}
+	return fmt.Sprintf("UnitFormat(%d)", int(f))
+}
+
+// FormatUnit returns the label of the unit in the format f. It returns an error if the unit has no label in the
+// format, such as a bale in UCUM.
+func FormatUnit(u Unit, f UnitFormat) (string, error) {
+	switch f {
+	case ExponentFormat:
+		return u.String(), nil
+	case SlashFormat:
+		return formatTerms(unitTerms(u), "·", "/"), nil
+	case PerFormat:
+		return formatTerms(unitTerms(u), " ", " per "), nil
+	case UnicodeFormat:
+		terms := unitTerms(u)
+		xs := make([]string, len(terms))
+		for i, t := range terms {
+			xs[i] = unicodeTerm(t.Unit, t.Exponent)
+		}
+		return strings.Join(xs, " "), nil
+	case UCUMFormat:
+		return FormatUCUM(u)
+	}
+	return "", fmt.Errorf("unknown unit format %d", int(f))
+}
+
+// StandardLabelAs returns the standard label for the specified unit label in the format f, eg "kg/ha" for
+// "kilograms per hectare" in SlashFormat.
+func StandardLabelAs(label string, f UnitFormat) (string, error) {
+	if label == "" {
+		return "", nil
+	}
+	u, err := UnitFromLabel(label)
+	if err != nil {
+		return "", fmt.Errorf("failed to get unit from label %s: %w", label, err)
+	}
+	return FormatUnit(u, f)
+}
+
+// UnitFromLabelAs returns the unit for a label written in the format f. Labels in UCUMFormat are read with ParseUCUM,
+// so ML is megalitres, and labels in the other formats are read with UnitFromLabel.
+func UnitFromLabelAs(label string, f UnitFormat) (Unit, error) {
+	if f != UCUMFormat {
+		return UnitFromLabel(label)
+	}
+	return ParseUCUM(label)
+}
+
+// unitTerms returns the simple or scaled units in u with their exponents, eg kg/ha is kg1 and ha-1.
+func unitTerms(u Unit) []UnitPower {
+	if n, d, ok := ratioParts(u); ok {
+		return []UnitPower{{Unit: n, Exponent: 1}, {Unit: d, Exponent: -1}}
+	}
+	if c, ok := u.(CompoundUnit); ok {
+		return c.Terms
+	}
+	return []UnitPower{{Unit: u, Exponent: 1}}
+}
+
+// formatTerms writes the terms with a positive exponent joined by mul, followed by each term with a negative exponent
+// after div. Exponents other than 1 are written with '^', eg m/s^2.
+func formatTerms(terms []UnitPower, mul, div string) string {
+	var num []string
+	var den []string
+	for _, t := range terms {
+		if t.Exponent < 0 {
+			den = append(den, formatTerm(t.Unit, -t.Exponent))
+		} else {
+			num = append(num, formatTerm(t.Unit, t.Exponent))
+		}
+	}
+	s := strings.Join(num, mul)
+	if s == "" {
+		s = "1"
+	}
+	for _, d := range den {
+		s += div + d
+	}
+	return s
+}
+
+// formatTerm writes the standard label of a simple or scaled unit raised to the power exp, eg m3^2 or (1000 ft2)^2.
+func formatTerm(u Unit, exp int) string {
+	s := u.String()
+	if exp == 1 {
+		return s
+	}
+	if _, ok := u.(ScaledUnit); ok {
+		s = "(" + s + ")"
+	}
+	return fmt.Sprintf("%s^%d", s, exp)
+}
+
+// unicodeTerm writes the symbol of a simple or scaled unit raised to the power exp with superscripts, eg ha⁻¹. A unit
+// that has its own exponent is put in parentheses, eg (ft²)⁻², so that it is read back as the same unit.
+func unicodeTerm(u Unit, exp int) string {
+	if _, power := splitExponent(u.String()); power != 1 && exp != 1 {
+		return "(" + symbolWithSuperscript(u, 1) + ")" + superscript(exp)
+	}
+	return symbolWithSuperscript(u, exp)
+}
@@ -0,0 +1,169 @@
+package convert
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUnit(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg  string
+		f    UnitFormat
+		want string
+	}{
+		"simple exponent":    {arg: "kilograms", f: ExponentFormat, want: "kg"},
+		"simple unicode":     {arg: "cubic metres", f: UnicodeFormat, want: "m³"},
+		"simple ucum":        {arg: "hectare", f: UCUMFormat, want: "har"},
+		"ratio exponent":     {arg: "kg/ha", f: ExponentFormat, want: "kg1ha-1"},
+		"ratio slash":        {arg: "kg1ha-1", f: SlashFormat, want: "kg/ha"},
+		"ratio per":          {arg: "kg/ha", f: PerFormat, want: "kg per ha"},
+		"ratio unicode":      {arg: "kg/ha", f: UnicodeFormat, want: "kg ha⁻¹"},
+		"ratio ucum":         {arg: "kg/ha", f: UCUMFormat, want: "kg/har"},
+		"volume slash":       {arg: "[m3]1ha-1", f: SlashFormat, want: "m3/ha"},
+		"volume ucum":        {arg: "gal/ac", f: UCUMFormat, want: "[gal_us]/[acr_us]"},
+		"megalitre ucum":     {arg: "Ml/ha", f: UCUMFormat, want: "ML/har"},
+		"dilution ucum":      {arg: "mg/kg", f: UCUMFormat, want: "mg/kg"},
+		"temperature ucum":   {arg: "degC", f: UCUMFormat, want: "Cel"},
+		"compound slash":     {arg: "t/ha/yr", f: SlashFormat, want: "t/ha/yr"},
+		"compound per":       {arg: "t/ha/yr", f: PerFormat, want: "t per ha per yr"},
+		"compound unicode":   {arg: "t/ha/yr", f: UnicodeFormat, want: "t ha⁻¹ yr⁻¹"},
+		"compound ucum":      {arg: "t/ha/yr", f: UCUMFormat, want: "t/har/(365.d)"},
+		"power slash":        {arg: "m/s2", f: SlashFormat, want: "m/s^2"},
+		"power ucum":         {arg: "m3/m2", f: UCUMFormat, want: "m3/m2"},
+		"squared power ucum": {arg: "kg/(ft2)^2", f: UCUMFormat, want: "kg/[sft_i]2"},
+		"reciprocal slash":   {arg: "1/s", f: SlashFormat, want: "1/s"},
+		"reciprocal ucum":    {arg: "1/s", f: UCUMFormat, want: "1/s"},
+		"scaled slash":       {arg: "lb/1000 ft2", f: SlashFormat, want: "lb/1000 ft2"},
+		"scaled ucum":        {arg: "lb/1000 ft2", f: UCUMFormat, want: "[lb_av]/(1000.[sft_i])"},
+		"count ucum":         {arg: "seeds/ac", f: UCUMFormat, want: "{count}/[acr_us]"},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := UnitFromLabel(c.arg)
+			assert.NoError(t, err)
+			got, err := FormatUnit(u, c.f)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestFormatUnit_Error(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg string
+		f   UnitFormat
+	}{
+		"no ucum code":       {arg: "bale/ha", f: UCUMFormat},
+		"decimal scale ucum": {arg: "l/0.5 ha", f: UCUMFormat},
+		"unknown format":     {arg: "kg", f: UnitFormat(99)},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := UnitFromLabel(c.arg)
+			assert.NoError(t, err)
+			_, err = FormatUnit(u, c.f)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestFormatUnit_RoundTrip checks that every unit written in every format is read back as the same unit.
+func TestFormatUnit_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	units := simpleUnits()
+	for _, label := range []string{"kg/ha", "Ml/ha", "gal/ac", "g/l", "t/ha/yr", "kg·m/s2", "1/s", "lb/1000 ft2",
+		"kg/100 l", "seeds/ac", "degC/h", "ac-ft/yr", "kg/(ft2)^2"} {
+		u, err := UnitFromLabel(label)
+		assert.NoError(t, err, label)
+		units = append(units, u)
+	}
+	formats := []UnitFormat{ExponentFormat, SlashFormat, PerFormat, UnicodeFormat, UCUMFormat}
+
+	for _, f := range formats {
+		f := f
+		t.Run(f.String(), func(t *testing.T) {
+			t.Parallel()
+			for _, u := range units {
+				s, err := FormatUnit(u, f)
+				if u.String() == BaleStandard.String() && f == UCUMFormat {
+					assert.Error(t, err)
+					continue
+				}
+				assert.NoError(t, err, u.String())
+				got, err := UnitFromLabelAs(s, f)
+				if !assert.NoError(t, err, s) {
+					continue
+				}
+				if f != UCUMFormat {
+					assert.Equal(t, u.String(), got.String(), s)
+				}
+				r, err := conversionRatio(u, got)
+				assert.NoError(t, err, s)
+				assert.Equal(t, big.NewRat(1, 1), r, s)
+			}
+		})
+	}
+}
+
+func TestStandardLabelAs(t *testing.T) {
+	t.Parallel()
+
+	got, err := StandardLabelAs("litres per hectare", SlashFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "l/ha", got)
+
+	got, err = StandardLabelAs("", UCUMFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	_, err = StandardLabelAs("furlongs", SlashFormat)
+	assert.Error(t, err)
+}
+
+func TestMeasurement_UnitAs(t *testing.T) {
+	t.Parallel()
+
+	mr := NewMassAreaRatioMeasure(1, Kilogram, Hectare)
+	got, err := mr.UnitAs(PerFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "kg per ha", got)
+
+	vr := NewVolumeAreaMeasurement(1, Gallon, Acre)
+	got, err = vr.UnitAs(UCUMFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "[gal_us]/[acr_us]", got)
+
+	cases := map[string]struct {
+		unitAs func(UnitFormat) (string, error)
+		f      UnitFormat
+		want   string
+	}{
+		"area":        {unitAs: AreaMeasurement{Value: 1, Unit: Hectare}.UnitAs, f: UCUMFormat, want: "har"},
+		"line":        {unitAs: LineMeasurement{Value: 1, Unit: Foot}.UnitAs, f: UCUMFormat, want: "[ft_i]"},
+		"mass":        {unitAs: MassMeasurement{Value: 1, Unit: Kilogram}.UnitAs, f: SlashFormat, want: "kg"},
+		"temperature": {unitAs: TemperatureMeasurement{Value: 1, Unit: Celsius}.UnitAs, f: UCUMFormat, want: "Cel"},
+		"time":        {unitAs: TimeMeasurement{Value: 1, Unit: Hour}.UnitAs, f: UCUMFormat, want: "h"},
+		"volume":      {unitAs: VolumeMeasurement{Value: 1, Unit: Gallon}.UnitAs, f: UCUMFormat, want: "[gal_us]"},
+	}
+	for name, c := range cases {
+		got, err := c.unitAs(c.f)
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.want, got, name)
+	}
+
+	_, err = VolumeMeasurement{Value: 1, Unit: Bale}.UnitAs(UCUMFormat)
+	assert.ErrorIs(t, err, ErrUnmappedUCUM)
+}
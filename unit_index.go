@@ -0,0 +1,152 @@
+package convert
+
+import (
+	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxCachedLabels is the most labels that each parsed label cache holds, so that the caches cannot grow without bound
+// when labels come from user input.
+const maxCachedLabels = 4096
+
+// caseSensitiveLabels are labels that are matched with their case, because ml is a millilitre and Ml a megalitre, as
+// are the UCUM codes mL and ML, and K is a kelvin but k is not a unit.
+var caseSensitiveLabels = []string{string(MillilitreStandard), "mL", string(MegalitreStandard), "ML",
+	string(KelvinStandard), "k"}
+
+// unitIndex maps every symbol, full name and alias in the simple unit tables to the units that match it, in the order
+// of the tables, so that a label is found with one map lookup rather than a scan of every label of every unit.
+type unitIndex struct {
+	// folded is keyed by the label with its case folded, as strings.EqualFold compares labels.
+	folded map[string][]Unit
+	// exact is keyed by the case-sensitive labels, and is checked before folded. A label that matches no unit, such
+	// as k, has a nil entry.
+	exact map[string][]Unit
+}
+
+// simpleUnitIndex is the index of the simple unit tables.
+var simpleUnitIndex = newUnitIndex(simpleUnits())
+
+// newUnitIndex returns the index of units. Units are added in order, so that the first unit for a label is the one
+// that a scan of the units with Matches would find.
+func newUnitIndex(units []Unit) unitIndex {
+	x := unitIndex{folded: make(map[string][]Unit), exact: make(map[string][]Unit)}
+	for _, u := range units {
+		added := make(map[string]bool)
+		for _, label := range unitLabels(u) {
+			key := string(appendFoldedLabel(nil, label))
+			if !added[key] {
+				added[key] = true
+				x.folded[key] = append(x.folded[key], u)
+			}
+		}
+	}
+	for _, label := range caseSensitiveLabels {
+		x.exact[label] = nil
+		for _, u := range units {
+			if unitMatches(u, label) {
+				x.exact[label] = append(x.exact[label], u)
+			}
+		}
+	}
+	return x
+}
+
+// lookup returns the units that match the label, most likely first. The slice is shared and must not be changed.
+// It does not allocate for labels of up to 64 bytes.
+func (x unitIndex) lookup(label string) []Unit {
+	if xs, ok := x.exact[label]; ok {
+		return xs
+	}
+	var buf [64]byte
+	return x.folded[string(appendFoldedLabel(buf[:0], label))]
+}
+
+// lookupSimpleUnit returns the first simple unit that matches the label, in the same order as UnitFromLabel.
+func lookupSimpleUnit(label string) (Unit, bool) {
+	if xs := simpleUnitIndex.lookup(label); len(xs) > 0 {
+		return xs[0], true
+	}
+	return nil, false
+}
+
+// hasSimpleUnit returns true if a simple unit with the dimension matches the label.
+func hasSimpleUnit(label string, dim Dimension) bool {
+	for _, u := range simpleUnitIndex.lookup(label) {
+		if DimensionOf(u) == dim {
+			return true
+		}
+	}
+	return false
+}
+
+// appendFoldedLabel appends the label to dst with the case of each rune folded, so that two labels are equal after
+// folding if and only if strings.EqualFold is true for them.
+func appendFoldedLabel(dst []byte, label string) []byte {
+	for i := 0; i < len(label); {
+		c := label[i]
+		if c < utf8.RuneSelf {
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			dst = append(dst, c)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(label[i:])
+		dst = utf8.AppendRune(dst, foldRune(r))
+		i += size
+	}
+	return dst
+}
+
+// foldRune returns the smallest rune that is equal to r under simple case folding, or its lower case if that is an
+// ASCII letter, eg k for the kelvin sign K.
+func foldRune(r rune) rune {
+	m := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < m {
+			m = f
+		}
+	}
+	if 'A' <= m && m <= 'Z' {
+		m += 'a' - 'A'
+	}
+	return m
+}
+
+// labelCache is a concurrency-safe cache of the results of parsing unit labels, keyed by the label. It stops adding
+// labels once it holds maxCachedLabels.
+type labelCache struct {
+	m sync.Map
+	n atomic.Int64
+}
+
+// load returns the cached value for the label.
+func (c *labelCache) load(label string) (any, bool) {
+	return c.m.Load(label)
+}
+
+// store caches the value for the label, unless the cache is full.
+func (c *labelCache) store(label string, v any) {
+	if c.n.Load() >= maxCachedLabels {
+		return
+	}
+	if _, loaded := c.m.LoadOrStore(label, v); !loaded {
+		c.n.Add(1)
+	}
+}
+
+// parsedUnits caches the units that UnitFromLabel parses from labels that are not simple units, eg kg/ha.
+var parsedUnits labelCache
+
+// compoundParts is the numerator and denominator returned by splitCompoundUnit.
+type compoundParts struct {
+	numerator   string
+	denominator string
+}
+
+// splitCompoundUnits caches the parts that splitCompoundUnit returns for a compound unit label.
+var splitCompoundUnits labelCache
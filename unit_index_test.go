@@ -0,0 +1,163 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scanUnitsMatching returns every simple unit that matches the label by scanning the unit tables with Matches.
+func scanUnitsMatching(label string) []Unit {
+	var xs []Unit
+	for _, u := range simpleUnits() {
+		if unitMatches(u, label) {
+			xs = append(xs, u)
+		}
+	}
+	return xs
+}
+
+func TestUnitIndex_MatchesScan(t *testing.T) {
+	t.Parallel()
+
+	for _, u := range simpleUnits() {
+		for _, label := range unitLabels(u) {
+			for _, s := range []string{label, strings.ToUpper(label), strings.ToLower(label)} {
+				assert.Equal(t, scanUnitsMatching(s), unitsMatching(s), "label %q", s)
+			}
+		}
+	}
+}
+
+func TestUnitIndex_Lookup(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		label string
+		want  Unit
+	}{
+		"millilitre":         {label: "ml", want: Millilitre},
+		"megalitre":          {label: "Ml", want: Megalitre},
+		"millilitre mixed":   {label: "mL", want: Millilitre},
+		"megalitre upper":    {label: "ML", want: Megalitre},
+		"upper case":         {label: "KG", want: Kilogram},
+		"full name":          {label: "Hectares", want: Hectare},
+		"micro sign":         {label: "µl", want: Microlitre},
+		"greek mu":           {label: "μl", want: Microlitre},
+		"kelvin sign":        {label: "\u212a", want: Kelvin},
+		"kelvin":             {label: "K", want: Kelvin},
+		"kilo is not a unit": {label: "k", want: nil},
+		"metre before time":  {label: "m", want: Metre},
+		"unknown":            {label: "furlong", want: nil},
+		"empty":              {label: "", want: nil},
+	}
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, ok := lookupSimpleUnit(c.label)
+			assert.Equal(t, c.want != nil, ok)
+			assert.Equal(t, c.want, u)
+			assert.Equal(t, scanUnitsMatching(c.label), unitsMatching(c.label))
+		})
+	}
+}
+
+func TestAppendFoldedLabel(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		a, b string
+	}{
+		"ascii":       {a: "Kg/Ha", b: "kg/ha"},
+		"micro sign":  {a: "µl", b: "Μl"},
+		"kelvin sign": {a: "\u212a", b: "k"},
+		"long s":      {a: "ſec", b: "SEC"},
+		"different":   {a: "ml", b: "mm"},
+		"length":      {a: "m", b: "mm"},
+		"superscript": {a: "m²", b: "M²"},
+	}
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			folded := string(appendFoldedLabel(nil, c.a)) == string(appendFoldedLabel(nil, c.b))
+			assert.Equal(t, strings.EqualFold(c.a, c.b), folded)
+		})
+	}
+}
+
+func TestUnitFromLabel_Cached(t *testing.T) {
+	t.Parallel()
+
+	for _, label := range []string{"bu/ac", "kg/m3", "[gal_us]/[acr_us]", "ml/l"} {
+		want, err := parseUnitLabel(label)
+		assert.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			u, err := UnitFromLabel(label)
+			assert.NoError(t, err)
+			assert.Equal(t, want, u, "label %s", label)
+		}
+	}
+	_, err := UnitFromLabel("furlongs/fortnight")
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+	_, ok := parsedUnits.load("furlongs/fortnight")
+	assert.False(t, ok, "errors are not cached")
+}
+
+func TestUnitFromLabel_NoAllocs(t *testing.T) {
+	for _, label := range []string{"kg", "Hectares", "ml", "Ml", "bu/ac", "kg1ha-1", "lb per acre"} {
+		_, _ = UnitFromLabel(label)
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = UnitFromLabel(label)
+		})
+		assert.Zero(t, allocs, "label %s", label)
+	}
+	_, _, _ = splitCompoundUnit("bu/ac")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _, _ = splitCompoundUnit("bu/ac")
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestLabelCache_Bounded(t *testing.T) {
+	t.Parallel()
+
+	var c labelCache
+	for i := 0; i < maxCachedLabels+10; i++ {
+		c.store(strings.Repeat("x", i), i)
+	}
+	_, ok := c.load(strings.Repeat("x", maxCachedLabels-1))
+	assert.True(t, ok)
+	_, ok = c.load(strings.Repeat("x", maxCachedLabels))
+	assert.False(t, ok)
+}
+
+func BenchmarkUnitFromLabel_Simple(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_, _ = UnitFromLabel("Hectares")
+	}
+}
+
+func BenchmarkUnitFromLabel_Compound(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_, _ = UnitFromLabel("bu/ac")
+	}
+}
+
+func BenchmarkParseUnitLabel_Compound(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_, _ = parseUnitLabel("bu/ac")
+	}
+}
+
+func BenchmarkValueFromTo(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_, _ = ValueFromTo(10, "bu/ac", "l/ha")
+	}
+}
@@ -0,0 +1,456 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unitName is the singular and plural name of a unit in a language.
+type unitName struct {
+	singular string
+	plural   string
+}
+
+// perWords are the words used for 'per' in compound unit names, by language. The first is used for rendering.
+var perWords = map[string][]string{
+	"en": {"per"},
+	"es": {"por"},
+	"pt": {"por"},
+	"fr": {"par"},
+	"de": {"pro", "je"},
+}
+
+// unitNames are the names of units in languages other than English, by language and standard unit label.
+var unitNames = map[string]map[string]unitName{
+	"es": {
+		"cm2":   {"centímetro cuadrado", "centímetros cuadrados"},
+		"m2":    {"metro cuadrado", "metros cuadrados"},
+		"km2":   {"kilómetro cuadrado", "kilómetros cuadrados"},
+		"ha":    {"hectárea", "hectáreas"},
+		"in2":   {"pulgada cuadrada", "pulgadas cuadradas"},
+		"ft2":   {"pie cuadrado", "pies cuadrados"},
+		"yd2":   {"yarda cuadrada", "yardas cuadradas"},
+		"mi2":   {"milla cuadrada", "millas cuadradas"},
+		"ac":    {"acre", "acres"},
+		"mm":    {"milímetro", "milímetros"},
+		"cm":    {"centímetro", "centímetros"},
+		"m":     {"metro", "metros"},
+		"km":    {"kilómetro", "kilómetros"},
+		"in":    {"pulgada", "pulgadas"},
+		"ft":    {"pie", "pies"},
+		"yd":    {"yarda", "yardas"},
+		"mi":    {"milla", "millas"},
+		"mg":    {"miligramo", "miligramos"},
+		"dg":    {"decigramo", "decigramos"},
+		"g":     {"gramo", "gramos"},
+		"kg":    {"kilogramo", "kilogramos"},
+		"t":     {"tonelada", "toneladas"},
+		"ozm":   {"onza", "onzas"},
+		"lb":    {"libra", "libras"},
+		"st":    {"stone", "stones"},
+		"ton":   {"tonelada corta", "toneladas cortas"},
+		"q":     {"quintal", "quintales"},
+		"s":     {"segundo", "segundos"},
+		"min":   {"minuto", "minutos"},
+		"h":     {"hora", "horas"},
+		"d":     {"día", "días"},
+		"wk":    {"semana", "semanas"},
+		"mo":    {"mes", "meses"},
+		"yr":    {"año", "años"},
+		"ul":    {"microlitro", "microlitros"},
+		"ml":    {"mililitro", "mililitros"},
+		"cl":    {"centilitro", "centilitros"},
+		"dl":    {"decilitro", "decilitros"},
+		"l":     {"litro", "litros"},
+		"kl":    {"kilolitro", "kilolitros"},
+		"dal":   {"decalitro", "decalitros"},
+		"hl":    {"hectolitro", "hectolitros"},
+		"Ml":    {"megalitro", "megalitros"},
+		"cm3":   {"centímetro cúbico", "centímetros cúbicos"},
+		"m3":    {"metro cúbico", "metros cúbicos"},
+		"gal":   {"galón", "galones"},
+		"floz":  {"onza líquida", "onzas líquidas"},
+		"qt":    {"cuarto de galón", "cuartos de galón"},
+		"pt":    {"pinta", "pintas"},
+		"in3":   {"pulgada cúbica", "pulgadas cúbicas"},
+		"ft3":   {"pie cúbico", "pies cúbicos"},
+		"yd3":   {"yarda cúbica", "yardas cúbicas"},
+		"ac-ft": {"acre-pie", "acres-pie"},
+		"ac-in": {"acre-pulgada", "acres-pulgada"},
+		"bu":    {"bushel", "bushels"},
+		"bale":  {"fardo", "fardos"},
+
+		"K":        {"kelvin", "kelvin"},
+		"degC":     {"grado Celsius", "grados Celsius"},
+		"degF":     {"grado Fahrenheit", "grados Fahrenheit"},
+		"umol":     {"micromol", "micromoles"},
+		"mmol":     {"milimol", "milimoles"},
+		"mol":      {"mol", "moles"},
+		"kmol":     {"kilomol", "kilomoles"},
+		"count":    {"unidad", "unidades"},
+		"doz":      {"docena", "docenas"},
+		"thousand": {"millar", "millares"},
+		"%":        {"por ciento", "por ciento"},
+		"permille": {"por mil", "por mil"},
+		"ppm":      {"parte por millón", "partes por millón"},
+		"ppb":      {"parte por mil millones", "partes por mil millones"},
+		"cwt":      {"quintal corto", "quintales cortos"},
+	},
+	"pt": {
+		"cm2":   {"centímetro quadrado", "centímetros quadrados"},
+		"m2":    {"metro quadrado", "metros quadrados"},
+		"km2":   {"quilômetro quadrado", "quilômetros quadrados"},
+		"ha":    {"hectare", "hectares"},
+		"in2":   {"polegada quadrada", "polegadas quadradas"},
+		"ft2":   {"pé quadrado", "pés quadrados"},
+		"yd2":   {"jarda quadrada", "jardas quadradas"},
+		"mi2":   {"milha quadrada", "milhas quadradas"},
+		"ac":    {"acre", "acres"},
+		"mm":    {"milímetro", "milímetros"},
+		"cm":    {"centímetro", "centímetros"},
+		"m":     {"metro", "metros"},
+		"km":    {"quilômetro", "quilômetros"},
+		"in":    {"polegada", "polegadas"},
+		"ft":    {"pé", "pés"},
+		"yd":    {"jarda", "jardas"},
+		"mi":    {"milha", "milhas"},
+		"mg":    {"miligrama", "miligramas"},
+		"dg":    {"decigrama", "decigramas"},
+		"g":     {"grama", "gramas"},
+		"kg":    {"quilograma", "quilogramas"},
+		"t":     {"tonelada", "toneladas"},
+		"ozm":   {"onça", "onças"},
+		"lb":    {"libra", "libras"},
+		"st":    {"stone", "stones"},
+		"ton":   {"tonelada curta", "toneladas curtas"},
+		"q":     {"quintal", "quintais"},
+		"s":     {"segundo", "segundos"},
+		"min":   {"minuto", "minutos"},
+		"h":     {"hora", "horas"},
+		"d":     {"dia", "dias"},
+		"wk":    {"semana", "semanas"},
+		"mo":    {"mês", "meses"},
+		"yr":    {"ano", "anos"},
+		"ul":    {"microlitro", "microlitros"},
+		"ml":    {"mililitro", "mililitros"},
+		"cl":    {"centilitro", "centilitros"},
+		"dl":    {"decilitro", "decilitros"},
+		"l":     {"litro", "litros"},
+		"kl":    {"quilolitro", "quilolitros"},
+		"dal":   {"decalitro", "decalitros"},
+		"hl":    {"hectolitro", "hectolitros"},
+		"Ml":    {"megalitro", "megalitros"},
+		"cm3":   {"centímetro cúbico", "centímetros cúbicos"},
+		"m3":    {"metro cúbico", "metros cúbicos"},
+		"gal":   {"galão", "galões"},
+		"floz":  {"onça líquida", "onças líquidas"},
+		"qt":    {"quarto", "quartos"},
+		"pt":    {"pinta", "pintas"},
+		"in3":   {"polegada cúbica", "polegadas cúbicas"},
+		"ft3":   {"pé cúbico", "pés cúbicos"},
+		"yd3":   {"jarda cúbica", "jardas cúbicas"},
+		"ac-ft": {"acre-pé", "acres-pé"},
+		"ac-in": {"acre-polegada", "acres-polegada"},
+		"bu":    {"bushel", "bushels"},
+		"bale":  {"fardo", "fardos"},
+
+		"K":        {"kelvin", "kelvins"},
+		"degC":     {"grau Celsius", "graus Celsius"},
+		"degF":     {"grau Fahrenheit", "graus Fahrenheit"},
+		"umol":     {"micromol", "micromols"},
+		"mmol":     {"milimol", "milimols"},
+		"mol":      {"mol", "mols"},
+		"kmol":     {"quilomol", "quilomols"},
+		"count":    {"unidade", "unidades"},
+		"doz":      {"dúzia", "dúzias"},
+		"thousand": {"milheiro", "milheiros"},
+		"%":        {"por cento", "por cento"},
+		"permille": {"por mil", "por mil"},
+		"ppm":      {"parte por milhão", "partes por milhão"},
+		"ppb":      {"parte por bilhão", "partes por bilhão"},
+		"cwt":      {"quintal curto", "quintais curtos"},
+	},
+	"fr": {
+		"cm2":   {"centimètre carré", "centimètres carrés"},
+		"m2":    {"mètre carré", "mètres carrés"},
+		"km2":   {"kilomètre carré", "kilomètres carrés"},
+		"ha":    {"hectare", "hectares"},
+		"in2":   {"pouce carré", "pouces carrés"},
+		"ft2":   {"pied carré", "pieds carrés"},
+		"yd2":   {"yard carré", "yards carrés"},
+		"mi2":   {"mille carré", "milles carrés"},
+		"ac":    {"acre", "acres"},
+		"mm":    {"millimètre", "millimètres"},
+		"cm":    {"centimètre", "centimètres"},
+		"m":     {"mètre", "mètres"},
+		"km":    {"kilomètre", "kilomètres"},
+		"in":    {"pouce", "pouces"},
+		"ft":    {"pied", "pieds"},
+		"yd":    {"yard", "yards"},
+		"mi":    {"mille", "milles"},
+		"mg":    {"milligramme", "milligrammes"},
+		"dg":    {"décigramme", "décigrammes"},
+		"g":     {"gramme", "grammes"},
+		"kg":    {"kilogramme", "kilogrammes"},
+		"t":     {"tonne", "tonnes"},
+		"ozm":   {"once", "onces"},
+		"lb":    {"livre", "livres"},
+		"st":    {"stone", "stones"},
+		"ton":   {"tonne courte", "tonnes courtes"},
+		"q":     {"quintal", "quintaux"},
+		"s":     {"seconde", "secondes"},
+		"min":   {"minute", "minutes"},
+		"h":     {"heure", "heures"},
+		"d":     {"jour", "jours"},
+		"wk":    {"semaine", "semaines"},
+		"mo":    {"mois", "mois"},
+		"yr":    {"an", "ans"},
+		"ul":    {"microlitre", "microlitres"},
+		"ml":    {"millilitre", "millilitres"},
+		"cl":    {"centilitre", "centilitres"},
+		"dl":    {"décilitre", "décilitres"},
+		"l":     {"litre", "litres"},
+		"kl":    {"kilolitre", "kilolitres"},
+		"dal":   {"décalitre", "décalitres"},
+		"hl":    {"hectolitre", "hectolitres"},
+		"Ml":    {"mégalitre", "mégalitres"},
+		"cm3":   {"centimètre cube", "centimètres cubes"},
+		"m3":    {"mètre cube", "mètres cubes"},
+		"gal":   {"gallon", "gallons"},
+		"floz":  {"once liquide", "onces liquides"},
+		"qt":    {"quart", "quarts"},
+		"pt":    {"pinte", "pintes"},
+		"in3":   {"pouce cube", "pouces cubes"},
+		"ft3":   {"pied cube", "pieds cubes"},
+		"yd3":   {"yard cube", "yards cubes"},
+		"ac-ft": {"acre-pied", "acres-pieds"},
+		"ac-in": {"acre-pouce", "acres-pouces"},
+		"bu":    {"boisseau", "boisseaux"},
+		"bale":  {"balle", "balles"},
+
+		"K":        {"kelvin", "kelvins"},
+		"degC":     {"degré Celsius", "degrés Celsius"},
+		"degF":     {"degré Fahrenheit", "degrés Fahrenheit"},
+		"umol":     {"micromole", "micromoles"},
+		"mmol":     {"millimole", "millimoles"},
+		"mol":      {"mole", "moles"},
+		"kmol":     {"kilomole", "kilomoles"},
+		"count":    {"unité", "unités"},
+		"doz":      {"douzaine", "douzaines"},
+		"thousand": {"millier", "milliers"},
+		"%":        {"pour cent", "pour cent"},
+		"permille": {"pour mille", "pour mille"},
+		"ppm":      {"partie par million", "parties par million"},
+		"ppb":      {"partie par milliard", "parties par milliard"},
+		"cwt":      {"quintal court", "quintaux courts"},
+	},
+	"de": {
+		"cm2":   {"Quadratzentimeter", "Quadratzentimeter"},
+		"m2":    {"Quadratmeter", "Quadratmeter"},
+		"km2":   {"Quadratkilometer", "Quadratkilometer"},
+		"ha":    {"Hektar", "Hektar"},
+		"in2":   {"Quadratzoll", "Quadratzoll"},
+		"ft2":   {"Quadratfuß", "Quadratfuß"},
+		"yd2":   {"Quadratyard", "Quadratyard"},
+		"mi2":   {"Quadratmeile", "Quadratmeilen"},
+		"ac":    {"Acre", "Acres"},
+		"mm":    {"Millimeter", "Millimeter"},
+		"cm":    {"Zentimeter", "Zentimeter"},
+		"m":     {"Meter", "Meter"},
+		"km":    {"Kilometer", "Kilometer"},
+		"in":    {"Zoll", "Zoll"},
+		"ft":    {"Fuß", "Fuß"},
+		"yd":    {"Yard", "Yard"},
+		"mi":    {"Meile", "Meilen"},
+		"mg":    {"Milligramm", "Milligramm"},
+		"dg":    {"Dezigramm", "Dezigramm"},
+		"g":     {"Gramm", "Gramm"},
+		"kg":    {"Kilogramm", "Kilogramm"},
+		"t":     {"Tonne", "Tonnen"},
+		"ozm":   {"Unze", "Unzen"},
+		"lb":    {"Pfund", "Pfund"},
+		"st":    {"Stone", "Stone"},
+		"ton":   {"amerikanische Tonne", "amerikanische Tonnen"},
+		"q":     {"Doppelzentner", "Doppelzentner"},
+		"s":     {"Sekunde", "Sekunden"},
+		"min":   {"Minute", "Minuten"},
+		"h":     {"Stunde", "Stunden"},
+		"d":     {"Tag", "Tage"},
+		"wk":    {"Woche", "Wochen"},
+		"mo":    {"Monat", "Monate"},
+		"yr":    {"Jahr", "Jahre"},
+		"ul":    {"Mikroliter", "Mikroliter"},
+		"ml":    {"Milliliter", "Milliliter"},
+		"cl":    {"Zentiliter", "Zentiliter"},
+		"dl":    {"Deziliter", "Deziliter"},
+		"l":     {"Liter", "Liter"},
+		"kl":    {"Kiloliter", "Kiloliter"},
+		"dal":   {"Dekaliter", "Dekaliter"},
+		"hl":    {"Hektoliter", "Hektoliter"},
+		"Ml":    {"Megaliter", "Megaliter"},
+		"cm3":   {"Kubikzentimeter", "Kubikzentimeter"},
+		"m3":    {"Kubikmeter", "Kubikmeter"},
+		"gal":   {"Gallone", "Gallonen"},
+		"floz":  {"Flüssigunze", "Flüssigunzen"},
+		"qt":    {"Quart", "Quart"},
+		"pt":    {"Pint", "Pint"},
+		"in3":   {"Kubikzoll", "Kubikzoll"},
+		"ft3":   {"Kubikfuß", "Kubikfuß"},
+		"yd3":   {"Kubikyard", "Kubikyard"},
+		"ac-ft": {"Acre-Foot", "Acre-Feet"},
+		"ac-in": {"Acre-Inch", "Acre-Inches"},
+		"bu":    {"Bushel", "Bushel"},
+		"bale":  {"Ballen", "Ballen"},
+
+		"K":        {"Kelvin", "Kelvin"},
+		"degC":     {"Grad Celsius", "Grad Celsius"},
+		"degF":     {"Grad Fahrenheit", "Grad Fahrenheit"},
+		"umol":     {"Mikromol", "Mikromol"},
+		"mmol":     {"Millimol", "Millimol"},
+		"mol":      {"Mol", "Mol"},
+		"kmol":     {"Kilomol", "Kilomol"},
+		"count":    {"Stück", "Stück"},
+		"doz":      {"Dutzend", "Dutzend"},
+		"thousand": {"Tausend", "Tausend"},
+		"%":        {"Prozent", "Prozent"},
+		"permille": {"Promille", "Promille"},
+		"ppm":      {"Teil pro Million", "Teile pro Million"},
+		"ppb":      {"Teil pro Milliarde", "Teile pro Milliarde"},
+		"cwt":      {"amerikanischer Zentner", "amerikanische Zentner"},
+	},
+}
+
+// localizedLabels maps the folded singular and plural unit names in each language to the standard unit label.
+var localizedLabels = func() map[string]map[string]string {
+	m := make(map[string]map[string]string, len(unitNames))
+	for lang, names := range unitNames {
+		m[lang] = make(map[string]string, 2*len(names))
+		for label, n := range names {
+			m[lang][foldName(n.singular)] = label
+			m[lang][foldName(n.plural)] = label
+		}
+	}
+	return m
+}()
+
+// accentFolds are the replacements used to compare unit names without accents.
+var accentFolds = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ß", "ss",
+)
+
+// foldName returns a unit name in lower case, without accents and with single spaces, for comparison.
+func foldName(s string) string {
+	return accentFolds.Replace(strings.Join(strings.Fields(strings.ToLower(s)), " "))
+}
+
+// language returns the language subtag of the locale in lower case, eg "pt" for pt-BR.
+func language(loc Locale) string {
+	tag := strings.ReplaceAll(string(loc), "_", "-")
+	l, _, _ := strings.Cut(tag, "-")
+	return strings.ToLower(l)
+}
+
+// UnitFromLocalizedLabel returns the unit for a label that may use the unit names of the language of the locale,
+// such as "hectárea", "litros por hectárea" or "quintal/ha" in es. Names are matched without regard to case or
+// accents, and compound units can use the slash form or the word for 'per' in the language. The unit is the same as
+// UnitFromLabel returns for the equivalent English label, and labels such as "kg/ha" are resolved by UnitFromLabel.
+func UnitFromLocalizedLabel(label string, loc Locale) (Unit, error) {
+	lang := language(loc)
+	if _, ok := perWords[lang]; !ok {
+		return nil, fmt.Errorf("unsupported language: %s", loc)
+	}
+	if u, err := UnitFromLabel(localizedStandardLabel(label, lang)); err == nil {
+		return u, nil
+	}
+	for _, sep := range append([]string{"/"}, perWords[lang]...) {
+		n, d, ok := cutWord(label, sep)
+		if !ok {
+			continue
+		}
+		u, err := UnitFromLabel(localizedStandardLabel(n, lang) + "/" + localizedStandardLabel(d, lang))
+		if err == nil {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("unhandled unit label: %s", label)
+}
+
+// localizedStandardLabel returns the standard label for a unit name in the language, or s if it is not a name.
+func localizedStandardLabel(s, lang string) string {
+	if label, ok := localizedLabels[lang][foldName(s)]; ok {
+		return label
+	}
+	return strings.TrimSpace(s)
+}
+
+// cutWord slices s around the separator sep, which must be surrounded by white space unless it is "/".
+func cutWord(s, sep string) (string, string, bool) {
+	if sep == "/" {
+		return strings.Cut(s, sep)
+	}
+	fields := strings.Fields(s)
+	for i, f := range fields {
+		if i > 0 && i < len(fields)-1 && strings.EqualFold(f, sep) {
+			return strings.Join(fields[:i], " "), strings.Join(fields[i+1:], " "), true
+		}
+	}
+	return "", "", false
+}
+
+// LocalizedFullWord returns the name of the unit in the language of the locale, eg "kilogramos por hectárea" in es.
+// English names are returned for a language, or a unit, that has no translation.
+func (l UnitLabel) LocalizedFullWord(loc Locale) string {
+	if l.unit == nil {
+		return ""
+	}
+	lang := language(loc)
+	if n, d, ok := ratioParts(l.unit); ok {
+		return localizedName(n, lang, true) + " " + perWord(lang) + " " + localizedName(d, lang, false)
+	}
+	return localizedName(l.unit, lang, false)
+}
+
+// LocalizedFullWordFor returns the name of the unit in the language of the locale for the value, pluralised unless
+// the value is 1 or -1, eg "2,5 litros" in es. English names are returned for a language, or a unit, that has no
+// translation.
+func (l UnitLabel) LocalizedFullWordFor(value float64, loc Locale) string {
+	if l.unit == nil {
+		return ""
+	}
+	lang := language(loc)
+	plural := value != 1 && value != -1
+	if n, d, ok := ratioParts(l.unit); ok {
+		return localizedName(n, lang, plural) + " " + perWord(lang) + " " + localizedName(d, lang, false)
+	}
+	return localizedName(l.unit, lang, plural)
+}
+
+// localizedName returns the singular or plural name of a unit in the language, falling back to the English name.
+func localizedName(u Unit, lang string, plural bool) string {
+	if n, d, ok := ratioParts(u); ok {
+		return localizedName(n, lang, plural) + " " + perWord(lang) + " " + localizedName(d, lang, false)
+	}
+	if n, ok := unitNames[lang][u.String()]; ok {
+		if plural {
+			return n.plural
+		}
+		return n.singular
+	}
+	if plural {
+		return pluralName(u)
+	}
+	return singularName(u)
+}
+
+// perWord returns the word for 'per' in the language, falling back to English.
+func perWord(lang string) string {
+	if w, ok := perWords[lang]; ok {
+		return w[0]
+	}
+	return perWords["en"][0]
+}
@@ -0,0 +1,146 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitFromLocalizedLabel(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg     string
+		locale  Locale
+		want    Unit
+		wantErr bool
+	}{
+		"es hectárea":             {arg: "hectárea", locale: LocaleES, want: Hectare},
+		"es without accent":       {arg: "HECTAREA", locale: LocaleES, want: Hectare},
+		"es plural":               {arg: "litros", locale: LocaleES, want: Litre},
+		"es per word":             {arg: "litros por hectárea", locale: LocaleES, want: VolumeAreaRatioUnit{Litre, Hectare}},
+		"es quintal slash":        {arg: "quintal/ha", locale: LocaleES, want: MassAreaRatioUnit{Quintal, Hectare}},
+		"es multi-word name":      {arg: "toneladas cortas por acre", locale: LocaleES, want: MassAreaRatioUnit{Ton, Acre}},
+		"es symbol":               {arg: "kg/ha", locale: LocaleES, want: MassAreaRatioUnit{Kilogram, Hectare}},
+		"pt quilogramas":          {arg: "quilogramas por hectare", locale: LocalePtBR, want: MassAreaRatioUnit{Kilogram, Hectare}},
+		"pt language only":        {arg: "galões", locale: "pt", want: Gallon},
+		"fr litres par hectare":   {arg: "litres par hectare", locale: LocaleFR, want: VolumeAreaRatioUnit{Litre, Hectare}},
+		"fr mètre carré":          {arg: "mètres carrés", locale: LocaleFR, want: SquareMetre},
+		"de pro":                  {arg: "Kilogramm pro Hektar", locale: LocaleDE, want: MassAreaRatioUnit{Kilogram, Hectare}},
+		"de je":                   {arg: "Liter je Hektar", locale: "de-AT", want: VolumeAreaRatioUnit{Litre, Hectare}},
+		"de sharp s":              {arg: "Kubikfuss", locale: LocaleDE, want: CubicFoot},
+		"de megalitre":            {arg: "Megaliter", locale: LocaleDE, want: Megalitre},
+		"en falls back":           {arg: "litres per hectare", locale: LocaleEnUS, want: VolumeAreaRatioUnit{Litre, Hectare}},
+		"other language's name":   {arg: "hectárea", locale: LocaleDE, wantErr: true},
+		"unknown":                 {arg: "xx", locale: LocaleES, wantErr: true},
+		"unsupported language":    {arg: "ettari", locale: "it", wantErr: true},
+		"per word without parts":  {arg: "por hectárea", locale: LocaleES, wantErr: true},
+		"compound per parts":      {arg: "horas por hectárea", locale: LocaleES, want: CompoundUnit{[]UnitPower{{Hour, 1}, {Hectare, -1}}}},
+		"non-separating per word": {arg: "litrospor", locale: LocaleES, wantErr: true},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnitFromLocalizedLabel(c.arg, c.locale)
+			assert.Equal(t, c.wantErr, err != nil, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestUnitLabel_LocalizedFullWord(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		arg          Unit
+		locale       Locale
+		want         string
+		wantSingular string
+		wantPlural   string
+	}{
+		"es hectare": {
+			arg:          Hectare,
+			locale:       LocaleES,
+			want:         "hectárea",
+			wantSingular: "hectárea",
+			wantPlural:   "hectáreas",
+		},
+		"es litres per hectare": {
+			arg:          VolumeAreaRatioUnit{Litre, Hectare},
+			locale:       LocaleES,
+			want:         "litros por hectárea",
+			wantSingular: "litro por hectárea",
+			wantPlural:   "litros por hectárea",
+		},
+		"pt-BR quintal": {
+			arg:          Quintal,
+			locale:       LocalePtBR,
+			want:         "quintal",
+			wantSingular: "quintal",
+			wantPlural:   "quintais",
+		},
+		"fr kilograms per hectare": {
+			arg:          MassAreaRatioUnit{Kilogram, Hectare},
+			locale:       LocaleFR,
+			want:         "kilogrammes par hectare",
+			wantSingular: "kilogramme par hectare",
+			wantPlural:   "kilogrammes par hectare",
+		},
+		"de tonne": {
+			arg:          Tonne,
+			locale:       LocaleDE,
+			want:         "Tonne",
+			wantSingular: "Tonne",
+			wantPlural:   "Tonnen",
+		},
+		"en": {
+			arg:          CubicFoot,
+			locale:       LocaleEnAU,
+			want:         "cubic foot",
+			wantSingular: "cubic foot",
+			wantPlural:   "cubic feet",
+		},
+		"unsupported language falls back to English": {
+			arg:          MassAreaRatioUnit{Pound, Acre},
+			locale:       "it",
+			want:         "pounds per acre",
+			wantSingular: "pound per acre",
+			wantPlural:   "pounds per acre",
+		},
+	}
+
+	for name, c := range cases {
+		name, c := name, c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			l, err := NewUnitLabel(c.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, l.LocalizedFullWord(c.locale))
+			assert.Equal(t, c.wantSingular, l.LocalizedFullWordFor(1, c.locale))
+			assert.Equal(t, c.wantPlural, l.LocalizedFullWordFor(2.5, c.locale))
+		})
+	}
+}
+
+func TestUnitNames(t *testing.T) {
+	t.Parallel()
+
+	// Every unit has a name in every language, and no name is used for two units.
+	for lang, names := range unitNames {
+		for _, u := range simpleUnits() {
+			_, ok := names[u.String()]
+			assert.True(t, ok, "%s has no %s name", u, lang)
+		}
+		seen := map[string]string{}
+		for label, n := range names {
+			for _, s := range []string{n.singular, n.plural} {
+				if other, ok := seen[foldName(s)]; ok {
+					assert.Equal(t, other, label, "%s name %s is used for %s and %s", lang, s, other, label)
+				}
+				seen[foldName(s)] = label
+			}
+		}
+	}
+}
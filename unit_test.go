@@ -42,6 +42,8 @@ func TestIsMassUnit(t *testing.T) {
 	assert.True(t, IsMassUnit("lb"))
 	assert.True(t, IsMassUnit("oz"))
 	assert.True(t, IsMassUnit("st"))
+	assert.True(t, IsMassUnit("quintals"))
+	assert.False(t, IsMassUnit("qq"), "qq is the 46 kg Spanish quintal, not the metric quintal")
 	assert.False(t, IsMassUnit("m2"))
 	assert.False(t, IsMassUnit("m3"))
 	assert.False(t, IsMassUnit("l"))
@@ -30,6 +30,8 @@ const (
 	AcreInchStandard        Volume = "ac-in" // acre-inches
 	BushelStandard          Volume = "bu"    // grain
 	BaleStandard            Volume = "bale"  // cotton
+
+	ImperialGallonStandard Volume = "imp gal" // UK gallon, not in the unit tables
 )
 
 // String return the string representation of the volume unit
@@ -42,7 +44,7 @@ type VolumeUnit struct {
 	full       string
 	fancy      string
 	aliases    []string
-	conversion float64
+	conversion factor
 }
 
 // String returns the string representation of the base volume unit.
@@ -52,8 +54,11 @@ func (u VolumeUnit) String() string {
 
 // Matches returns true if s matches the volume unit - this must be case-sensitive because ml is not Ml.
 func (u VolumeUnit) Matches(s string) bool {
-	// Deal with the special case of Ml (megalitre) and ml (millilitre).
-	if u.unit == MillilitreStandard && s == "Ml" {
+	// Deal with the special case of Ml (megalitre) and ml (millilitre). The UCUM codes ML and mL are read the same way.
+	if u.unit == MillilitreStandard && (s == "Ml" || s == "ML") {
+		return false
+	}
+	if u.unit == MegalitreStandard && (s == "ml" || s == "mL") {
 		return false
 	}
 	if strings.EqualFold(u.String(), s) ||
@@ -124,7 +129,7 @@ var Microlitre = VolumeUnit{
 	full:       "microlitre",
 	fancy:      string(MicrolitreStandard),
 	aliases:    []string{"microlitres", "microliter", "microliters", "µl", "mcL"},
-	conversion: 0.000001,
+	conversion: exactFactor("0.000001"),
 }
 
 var Millilitre = VolumeUnit{
@@ -132,7 +137,7 @@ var Millilitre = VolumeUnit{
 	full:       "millilitre",
 	fancy:      string(MillilitreStandard),
 	aliases:    []string{"millilitres", "milliliter", "milliliters"},
-	conversion: 0.001,
+	conversion: exactFactor("0.001"),
 }
 
 var Centilitre = VolumeUnit{
@@ -140,7 +145,7 @@ var Centilitre = VolumeUnit{
 	full:       "centilitre",
 	fancy:      string(CentilitreStandard),
 	aliases:    []string{"centilitres", "centiliter", "centiliters"},
-	conversion: 0.01,
+	conversion: exactFactor("0.01"),
 }
 
 var Decilitre = VolumeUnit{
@@ -148,7 +153,7 @@ var Decilitre = VolumeUnit{
 	full:       "decilitre",
 	fancy:      string(DecilitreStandard),
 	aliases:    []string{"decilitres", "deciliter", "deciliters"},
-	conversion: 0.1,
+	conversion: exactFactor("0.1"),
 }
 
 var Litre = VolumeUnit{
@@ -156,7 +161,7 @@ var Litre = VolumeUnit{
 	full:       "litre",
 	fancy:      string(LitreStandard),
 	aliases:    []string{"litres", "liter", "liters"},
-	conversion: 1,
+	conversion: exactFactor("1"),
 }
 
 var Kilolitre = VolumeUnit{
@@ -164,7 +169,7 @@ var Kilolitre = VolumeUnit{
 	full:       "kilolitre",
 	fancy:      string(KilolitreStandard),
 	aliases:    []string{"kilolitres", "kiloliter", "kiloliters"},
-	conversion: 1000,
+	conversion: exactFactor("1000"),
 }
 
 var Decalitre = VolumeUnit{
@@ -172,7 +177,7 @@ var Decalitre = VolumeUnit{
 	full:       "decalitre",
 	fancy:      string(DecalitreStandard),
 	aliases:    []string{"decalitres", "decaliter", "decaliters"},
-	conversion: 10,
+	conversion: exactFactor("10"),
 }
 
 var Hectolitre = VolumeUnit{
@@ -180,7 +185,7 @@ var Hectolitre = VolumeUnit{
 	full:       "hectolitre",
 	fancy:      string(HectolitreStandard),
 	aliases:    []string{"hectolitres", "hectoliter", "hectoliters", "100l", "100 litres", "100 liters", "100 litre", "100 liter"},
-	conversion: 100,
+	conversion: exactFactor("100"),
 }
 
 var Megalitre = VolumeUnit{
@@ -188,7 +193,7 @@ var Megalitre = VolumeUnit{
 	full:       "megalitre",
 	fancy:      string(MegalitreStandard),
 	aliases:    []string{"megalitre", "megalitres", "megaliter", "megaliters"},
-	conversion: 1000000,
+	conversion: exactFactor("1000000"),
 }
 
 var CubicCentimetre = VolumeUnit{
@@ -196,7 +201,7 @@ var CubicCentimetre = VolumeUnit{
 	full:       "cubic centimetre",
 	fancy:      string(CubicCentimetreStandard),
 	aliases:    []string{"cm^3", "cubic centimetres", "cubic centimeter", "cubic centimeters", "cc"},
-	conversion: 0.001,
+	conversion: exactFactor("0.001"),
 }
 
 var CubicMetre = VolumeUnit{
@@ -204,7 +209,7 @@ var CubicMetre = VolumeUnit{
 	full:       "cubic metre",
 	fancy:      string(CubicMetreStandard),
 	aliases:    []string{"m^3", "cubic metres", "cubic meter", "cubic meters"},
-	conversion: 1000,
+	conversion: exactFactor("1000"),
 }
 
 var Gallon = VolumeUnit{
@@ -212,7 +217,7 @@ var Gallon = VolumeUnit{
 	full:       "gallon",
 	fancy:      string(GallonStandard),
 	aliases:    []string{"us gal", "us-gal", "us gallon", "us gallons", "gallon", "gallons"},
-	conversion: 3.78541,
+	conversion: exactFactor("3.785411784"), // US gallon, 231 in³
 }
 
 var FluidOunce = VolumeUnit{
@@ -220,7 +225,7 @@ var FluidOunce = VolumeUnit{
 	full:       "fluid ounce",
 	fancy:      string(FluidOunceStandard),
 	aliases:    []string{"fl oz", "us fl oz", "us-fluid-ounce", "us fluid ounce", "us fluid ounces", "fluid ounce", "fluid ounces"},
-	conversion: 0.0295735,
+	conversion: exactFactor("0.0295735295625"), // 1/128 US gal
 }
 
 var Quart = VolumeUnit{
@@ -228,7 +233,7 @@ var Quart = VolumeUnit{
 	full:       "quart",
 	fancy:      string(QuartStandard),
 	aliases:    []string{"us qt", "us-quart", "us quarts", "quart", "quarts"},
-	conversion: 0.946353,
+	conversion: exactFactor("0.946352946"), // 1/4 US gal
 }
 
 var Pint = VolumeUnit{
@@ -236,7 +241,7 @@ var Pint = VolumeUnit{
 	full:       "pint",
 	fancy:      string(PintStandard),
 	aliases:    []string{"us pt", "us-pint", "us pints", "pint", "pints"},
-	conversion: 0.473176,
+	conversion: exactFactor("0.473176473"), // 1/8 US gal
 }
 
 var CubicInch = VolumeUnit{
@@ -244,7 +249,7 @@ var CubicInch = VolumeUnit{
 	full:       "cubic inch",
 	fancy:      string(CubicInchStandard),
 	aliases:    []string{"in^3", "cubic inches"},
-	conversion: 0.0163871,
+	conversion: exactFactor("0.016387064"), // (0.0254 m)³
 }
 
 var CubicFoot = VolumeUnit{
@@ -252,7 +257,7 @@ var CubicFoot = VolumeUnit{
 	full:       "cubic foot",
 	fancy:      string(CubicFootStandard),
 	aliases:    []string{"ft^3", "cubic feet"},
-	conversion: 28.3168,
+	conversion: exactFactor("28.316846592"), // (0.3048 m)³
 }
 
 var CubicYard = VolumeUnit{
@@ -260,7 +265,7 @@ var CubicYard = VolumeUnit{
 	full:       "cubic yard",
 	fancy:      string(CubicYardStandard),
 	aliases:    []string{"yd^3", "cubic yards"},
-	conversion: 764.555,
+	conversion: exactFactor("764.554857984"), // (0.9144 m)³
 }
 
 var AcreInch = VolumeUnit{
@@ -268,7 +273,7 @@ var AcreInch = VolumeUnit{
 	full:       "acre inch",
 	fancy:      string(AcreInchStandard),
 	aliases:    []string{"acre inches"},
-	conversion: 102790.15312896,
+	conversion: exactFactor("102790.15312896"), // 1 ac × 1 in
 }
 
 var AcreFoot = VolumeUnit{
@@ -276,7 +281,7 @@ var AcreFoot = VolumeUnit{
 	full:       "acre foot",
 	fancy:      string(AcreFootStandard),
 	aliases:    []string{"acre feet"},
-	conversion: 1233480,
+	conversion: exactFactor("1233481.83754752"), // 1 ac × 1 ft
 }
 
 var Bushel = VolumeUnit{
@@ -284,7 +289,7 @@ var Bushel = VolumeUnit{
 	full:       "bushel",
 	fancy:      string(BushelStandard),
 	aliases:    []string{"bushels"},
-	conversion: 35.2391,
+	conversion: exactFactor("35.23907016688"), // US (Winchester) bushel, 2150.42 in³
 }
 
 var Bale = VolumeUnit{
@@ -292,13 +297,23 @@ var Bale = VolumeUnit{
 	full:       "bale",
 	fancy:      string(BaleStandard),
 	aliases:    []string{"bales"},
-	conversion: 480,
+	conversion: exactFactor("480"), // ref: https://www.cotton.org/tech/bale/bale-description.cfm
+}
+
+// ImperialGallon is the UK gallon. It is not in the unit tables, since gal is read as the US gallon, and is one of the
+// candidates that AmbiguousLabels and UnitFromLabelStrict report for gal.
+var ImperialGallon = VolumeUnit{
+	unit:       ImperialGallonStandard,
+	full:       "imperial gallon",
+	fancy:      string(ImperialGallonStandard),
+	aliases:    []string{"imperial gallons", "uk gal", "uk gallon", "uk gallons"},
+	conversion: exactFactor("4.54609"), // Weights and Measures Act 1985
 }
 
 // volumeUnitFromString returns the first volume unit that is a case-sensitive match for s, or an error if no match is found.
 func volumeUnitFromString(s string) (VolumeUnit, error) {
-	for _, u := range volumeUnits {
-		if u.Matches(s) {
+	for _, x := range simpleUnitIndex.lookup(s) {
+		if u, ok := x.(VolumeUnit); ok {
 			return u, nil
 		}
 	}
@@ -314,8 +329,13 @@ type VolumeMeasurement struct {
 // To converts a volume measurement to the specified unit.
 func (m VolumeMeasurement) To(unit VolumeUnit) VolumeMeasurement {
 	if m.Value != 0 {
-		m.Value = (m.Value * m.Unit.conversion) / unit.conversion
+		m.Value = scaleValue(m.Value, m.Unit.conversion, unit.conversion)
 	}
 	m.Unit = unit
 	return m
 }
+
+// UnitAs returns the unit of a volume measurement in the format f, eg "[gal_us]" in UCUMFormat.
+func (m VolumeMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(m.Unit, f)
+}
@@ -100,13 +100,11 @@ func NewVolumeAreaMeasurementFromUnitString(v float64, compoundUnit string) (Vol
 }
 
 func (vr VolumeAreaRatioMeasurement) To(toVolumeUnit VolumeUnit, toAreaUnit AreaUnit) VolumeAreaRatioMeasurement {
-	toVolume := vr.VolumeMeasurement.To(toVolumeUnit)
-	toArea := AreaMeasurement{Value: 1, Unit: vr.AreaUnit}.To(toAreaUnit)
-	toVolume.Value = toVolume.Value / toArea.Value
-	return VolumeAreaRatioMeasurement{
-		VolumeMeasurement: toVolume,
-		AreaUnit:          toAreaUnit,
+	v := vr.VolumeMeasurement.Value
+	if v != 0 {
+		v = scaleRatioValue(v, vr.VolumeMeasurement.Unit.conversion, vr.AreaUnit.conversion, toVolumeUnit.conversion, toAreaUnit.conversion)
 	}
+	return NewVolumeAreaMeasurement(v, toVolumeUnit, toAreaUnit)
 }
 
 // Value returns the value of the VolumeAreaRatioMeasurement
@@ -119,6 +117,11 @@ func (vr VolumeAreaRatioMeasurement) Unit() (string, error) {
 	return joinCompoundUnit(vr.VolumeMeasurement.Unit.String(), vr.AreaUnit.String())
 }
 
+// UnitAs returns the unit of the VolumeAreaRatioMeasurement in the format f, eg "l/ha" in SlashFormat.
+func (vr VolumeAreaRatioMeasurement) UnitAs(f UnitFormat) (string, error) {
+	return FormatUnit(VolumeAreaRatioUnit{Numerator: vr.VolumeMeasurement.Unit, Denominator: vr.AreaUnit}, f)
+}
+
 // volumeAreaUnitByName returns the first volume/area unit that is a case-sensitive match for s, or an error if no match is found.
 // func volumeAreaUnitByName(s string) (VolumePerArea, error) {
 // 	n, d := splitCompoundUnit(s)
@@ -100,7 +100,7 @@ func Test_volumeTo(t *testing.T) {
 		},
 		"1 acre inch to gallon": {
 			arg:  VolumeMeasurement{1, AcreInch},
-			want: VolumeMeasurement{27154.3, Gallon},
+			want: VolumeMeasurement{27154.2857, Gallon},
 		},
 		"1 acre foot to megalitre": {
 			arg:  VolumeMeasurement{1, AcreFoot},